@@ -0,0 +1,94 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+// Baseline holds the findings of a previous analysis, which are known and should not be reported again
+type Baseline struct {
+	findings map[string][]Finding
+}
+
+// NewBaseline creates a new baseline with the findings of a previous analysis
+func NewBaseline(findings []Finding) *Baseline {
+	baseline := &Baseline{
+		findings: make(map[string][]Finding, len(findings)),
+	}
+
+	for _, finding := range findings {
+		key := finding.identityKey()
+		baseline.findings[key] = append(baseline.findings[key], finding)
+	}
+
+	return baseline
+}
+
+// Filter returns only the findings that are not in the baseline. A finding is in the baseline when there is a known
+// finding with the same rule id, relative file and code sample, regardless of how many lines it moved. Findings are
+// matched in order and each known finding can only match one of them, the closest line being used to choose between
+// duplicates, so new duplicates are still reported
+func (b *Baseline) Filter(findings []Finding) []Finding {
+	matched := make(map[string][]bool, len(b.findings))
+	newFindings := make([]Finding, 0, len(findings))
+
+	for _, finding := range findings {
+		if !b.match(finding, matched) {
+			newFindings = append(newFindings, finding)
+		}
+	}
+
+	return newFindings
+}
+
+// match marks the closest known finding with the same identity that was not matched yet, returning false if there is
+// none left
+func (b *Baseline) match(finding Finding, matched map[string][]bool) bool {
+	key := finding.identityKey()
+
+	if _, ok := matched[key]; !ok {
+		matched[key] = make([]bool, len(b.findings[key]))
+	}
+
+	index := b.findClosest(finding, b.findings[key], matched[key])
+	if index < 0 {
+		return false
+	}
+
+	matched[key][index] = true
+
+	return true
+}
+
+// findClosest returns the index of the known finding closest to the finding line that was not matched yet, or -1 if
+// all of them were already matched
+func (b *Baseline) findClosest(finding Finding, known []Finding, matched []bool) int {
+	closest, closestDistance := -1, 0
+
+	for index := range known {
+		distance := abs(known[index].SourceLocation.WithBase(DefaultBase).Line -
+			finding.SourceLocation.WithBase(DefaultBase).Line)
+		if !matched[index] && (closest < 0 || distance < closestDistance) {
+			closest, closestDistance = index, distance
+		}
+	}
+
+	return closest
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFinding(id, filename, codeSample string, line int) Finding {
+	return Finding{
+		ID:         id,
+		CodeSample: codeSample,
+		SourceLocation: Location{
+			Filename: filename,
+			Line:     line,
+		},
+	}
+}
+
+func TestBaselineFilter(t *testing.T) {
+	baseline := NewBaseline([]Finding{
+		newTestFinding("HS-1", "main.go", `password := "123"`, 10),
+		newTestFinding("HS-2", "main.go", `exec(cmd)`, 20),
+		newTestFinding("HS-3", "main.go", `eval(code)`, 30),
+		newTestFinding("HS-3", "main.go", `eval(code)`, 60),
+	})
+
+	testCases := []struct {
+		name             string
+		findings         []Finding
+		expectedFindings []Finding
+	}{
+		{
+			name: "Should filter findings that moved a few lines",
+			findings: []Finding{
+				newTestFinding("HS-1", "main.go", `password := "123"`, 13),
+				newTestFinding("HS-2", "main.go", `exec(cmd)`, 17),
+			},
+			expectedFindings: []Finding{},
+		},
+		{
+			name: "Should filter findings that moved many lines",
+			findings: []Finding{
+				newTestFinding("HS-1", "main.go", `password := "123"`, 35),
+			},
+			expectedFindings: []Finding{},
+		},
+		{
+			name: "Should match the closest known duplicate",
+			findings: []Finding{
+				newTestFinding("HS-3", "main.go", `eval(code)`, 31),
+				newTestFinding("HS-3", "main.go", `eval(code)`, 58),
+				newTestFinding("HS-3", "main.go", `eval(code)`, 59),
+			},
+			expectedFindings: []Finding{
+				newTestFinding("HS-3", "main.go", `eval(code)`, 59),
+			},
+		},
+		{
+			name: "Should report findings with different rule, file or code sample",
+			findings: []Finding{
+				newTestFinding("HS-4", "main.go", `password := "123"`, 10),
+				newTestFinding("HS-1", "other.go", `password := "123"`, 10),
+				newTestFinding("HS-1", "main.go", `password := "456"`, 10),
+			},
+			expectedFindings: []Finding{
+				newTestFinding("HS-4", "main.go", `password := "123"`, 10),
+				newTestFinding("HS-1", "other.go", `password := "123"`, 10),
+				newTestFinding("HS-1", "main.go", `password := "456"`, 10),
+			},
+		},
+		{
+			name: "Should report new duplicates of a known finding",
+			findings: []Finding{
+				newTestFinding("HS-1", "main.go", `password := "123"`, 12),
+				newTestFinding("HS-1", "main.go", `password := "123"`, 11),
+			},
+			expectedFindings: []Finding{
+				newTestFinding("HS-1", "main.go", `password := "123"`, 11),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedFindings, baseline.Filter(testCase.findings))
+		})
+	}
+}
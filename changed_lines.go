@@ -0,0 +1,85 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+// LineRange represents an inclusive range of lines of a file, like the lines changed in a pull request
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// contains checks if the line is inside the range
+func (l LineRange) contains(line int) bool {
+	return line >= l.Start && line <= l.End
+}
+
+// SetChangedLines restricts the findings reported by the engine to the informed lines, which is useful to analyze only
+// the changes of a pull request. The map key is the file path relative to the project with forward slashes, the same
+// way as reported by git diff and Location.RelativeFilename, or the file path as it's reported in Location.Filename,
+// and findings of files that are not in the map are ignored. Findings without line, like the ones of files that don't
+// match a rule, are reported if the file is in the map. A nil map, which is the default, reports all findings
+func (e *Engine) SetChangedLines(changedLines map[string][]LineRange) {
+	e.changedLines = changedLines
+}
+
+// filterChangedLines removes the findings that are not inside the changed lines, if they are set
+func (e *Engine) filterChangedLines(findings []Finding) []Finding {
+	if e.changedLines == nil {
+		return findings
+	}
+
+	filtered := make([]Finding, 0, len(findings))
+
+	for index := range findings {
+		if e.isInChangedLines(findings[index].SourceLocation.WithBase(DefaultBase)) {
+			filtered = append(filtered, findings[index])
+		}
+	}
+
+	return filtered
+}
+
+// isInChangedLines checks if the location is inside any of the changed line ranges of its file
+func (e *Engine) isInChangedLines(location Location) bool {
+	lineRanges, ok := e.changedLineRanges(location)
+	if !ok {
+		return false
+	}
+
+	return location.Line <= 0 || containsLine(lineRanges, location.Line)
+}
+
+// containsLine checks if the line is inside any of the line ranges
+func containsLine(lineRanges []LineRange, line int) bool {
+	for _, lineRange := range lineRanges {
+		if lineRange.contains(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// changedLineRanges returns the changed line ranges of the location file, looking up the file by its relative path
+// first, and false if the file didn't change
+func (e *Engine) changedLineRanges(location Location) ([]LineRange, bool) {
+	if lineRanges, ok := e.changedLines[location.RelativeFilename]; ok {
+		return lineRanges, true
+	}
+
+	lineRanges, ok := e.changedLines[location.Filename]
+
+	return lineRanges, ok
+}
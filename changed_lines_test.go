@@ -0,0 +1,100 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngineRunWithChangedLines(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"main.go":  "package main",
+		"other.go": "package main",
+	})
+
+	mainPath := filepath.Join(projectPath, "main.go")
+	otherPath := filepath.Join(projectPath, "other.go")
+
+	rule := newRuleMock([]Finding{
+		{ID: "HS-1", SourceLocation: Location{Filename: mainPath, Line: 3}},
+		{ID: "HS-2", SourceLocation: Location{Filename: mainPath, Line: 10}},
+		{ID: "HS-3", SourceLocation: Location{Filename: mainPath, Line: 21}},
+		{ID: "HS-4", SourceLocation: Location{Filename: mainPath}},
+		{ID: "HS-5", SourceLocation: Location{Filename: otherPath, Line: 10}},
+		{ID: "HS-6", SourceLocation: Location{Filename: mainPath, Line: 0, Base: ZeroBased}},
+		{ID: "HS-7", SourceLocation: Location{Filename: mainPath, Line: 9, Base: ZeroBased}},
+	}, nil)
+
+	testCases := []struct {
+		name         string
+		changedLines map[string][]LineRange
+		expectedIDs  []string
+	}{
+		{
+			name:         "Should report only findings in changed lines",
+			changedLines: map[string][]LineRange{mainPath: {{Start: 1, End: 3}, {Start: 20, End: 25}}},
+			expectedIDs:  []string{"HS-1", "HS-3", "HS-4", "HS-6"},
+		},
+		{
+			name:         "Should find the changed lines by the path relative to the project",
+			changedLines: map[string][]LineRange{"main.go": {{Start: 1, End: 3}}},
+			expectedIDs:  []string{"HS-1", "HS-4", "HS-6"},
+		},
+		{
+			name:         "Should not report findings when no file changed",
+			changedLines: map[string][]LineRange{},
+		},
+		{
+			name:        "Should report all findings when changed lines are not set",
+			expectedIDs: []string{"HS-1", "HS-2", "HS-3", "HS-4", "HS-5", "HS-6", "HS-7"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			engine := NewEngine(0, ".go")
+			engine.SetChangedLines(testCase.changedLines)
+
+			findings, err := engine.Run(context.Background(), filepath.Join(projectPath, "main.go"), rule)
+			assert.NoError(t, err)
+
+			var ids []string
+			for _, finding := range findings {
+				ids = append(ids, finding.ID)
+			}
+
+			assert.Equal(t, testCase.expectedIDs, ids)
+		})
+	}
+}
+
+func TestEngineRunWithRelativeChangedLines(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"sub/a.go": "package sub",
+		"sub/b.go": "package sub",
+	})
+
+	engine := NewEngine(0, ".go")
+	engine.SetChangedLines(map[string][]LineRange{"sub/a.go": {{Start: 1, End: 1}}})
+
+	findings, err := engine.Run(context.Background(), projectPath, &pathRuleMock{})
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, filepath.Join(projectPath, "sub", "a.go"), findings[0].SourceLocation.Filename)
+}
@@ -16,11 +16,15 @@ package engine
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -39,7 +43,37 @@ type Finding struct {
 	CodeSample     string
 	Confidence     string
 	Description    string
+	CWEs           []string
+	Tags           []string
 	SourceLocation Location
+	// Captures holds the values of the capture groups of the regular expression that matched, where the key is the
+	// group name or the group number for unnamed groups. It's nil if the expression doesn't have capture groups
+	Captures map[string]string
+	// Fix holds the edit suggested to remediate the vulnerability, it's nil if the rule doesn't suggest one
+	Fix *Fix
+	// RuleSet holds the metadata of the rule set of the rule, it's empty if the rule was not ran as part of a set
+	RuleSet RuleSetMetadata
+	// Occurrence holds how many findings of the same rule with the same code sample are before this one in the file,
+	// so identical lines can be told apart. It's set by the engine
+	Occurrence int
+}
+
+// Fix represents a suggested text edit to remediate a finding, where the content of the file between the start and end
+// byte offsets, the end being exclusive, should be replaced by the replacement
+type Fix struct {
+	StartOffset int
+	EndOffset   int
+	Replacement string
+}
+
+// Fingerprint returns an identity of the finding that is stable across analyses, derived from the rule id, the file
+// path relative to the project, the code sample and the occurrence. Since the line and column are not used, it doesn't
+// change when unrelated code is added or removed before the vulnerable code, and since the occurrence is used,
+// identical lines of the same file have different fingerprints
+func (f *Finding) Fingerprint() string {
+	hash := sha256.Sum256([]byte(f.identityKey() + "\x00" + strconv.Itoa(f.Occurrence)))
+
+	return hex.EncodeToString(hash[:])
 }
 
 // Location represents the location of the vulnerability in a file
@@ -47,12 +81,44 @@ type Location struct {
 	Filename string
 	Line     int
 	Column   int
+
+	// Offset holds the byte offset of the beginning of the vulnerable code in the file content. Since findings
+	// without a position, like the NotMatch ones, also have it as 0, it should be used together with the line
+	Offset int
+	// RelativeFilename holds the file path relative to the analyzed project path, with forward slashes, so it's the
+	// same in any checkout of the project. It's set by the engine and it's empty when the finding isn't returned by it
+	RelativeFilename string
+	// Base holds from which number the line and column are counted, DefaultBase by default. The engine features that
+	// use the positions, like SetChangedLines, Baseline and the position formatters, convert it with WithBase
+	Base PositionBase
+}
+
+// Stats holds counters about an analysis, they are accumulated across all goroutines of the engine pool
+type Stats struct {
+	// FilesScanned holds the total of files that had all rules applied
+	FilesScanned int
+	// RulesEvaluated holds the total of Rule.Run calls, which is the total of files scanned times the total of rules,
+	// plus one for each UnitRule.RunUnit call
+	RulesEvaluated int
+	// MatchDuration holds the sum of the time spent running the rules in each file
+	MatchDuration time.Duration
+	// SkippedFiles holds the paths of the files that were not analyzed because they were bigger than the max file size
+	SkippedFiles []string
+	// Truncated holds if the analysis was stopped because the scan budget was exceeded, so the findings are partial
+	Truncated bool
 }
 
 // Engine contains all the engine necessary data
 type Engine struct {
-	poolSize   int
-	extensions []string
+	poolSize     int
+	extensions   []string
+	maxFileSize  int64
+	changedLines map[string][]LineRange
+	ruleFilter   ruleFilter
+	severities   map[string]string
+
+	continueOnError bool
+	scanBudget      time.Duration
 }
 
 // NewEngine creates a new engine instance with all necessary data.
@@ -65,94 +131,301 @@ func NewEngine(poolSize int, extensions ...string) *Engine {
 	}
 }
 
+// SetMaxFileSize sets the max size in bytes that a file can have to be analyzed, bigger files will be skipped and
+// reported in Stats.SkippedFiles. Zero or lower means that there is no limit, which is the default
+func (e *Engine) SetMaxFileSize(maxFileSize int64) {
+	e.maxFileSize = maxFileSize
+}
+
 // Run walks through projectPath and runs the method Rule.Run in a pool of goroutines
 // if an error is found when executes Rule.Run method it cancels current running go routines and return
 // valid findings and the error
-// nolint:funlen,gocyclo // necessary complexity, breaking this function will lead to an even more complex code
 func (e *Engine) Run(ctx context.Context, projectPath string, rules ...Rule) ([]Finding, error) {
+	findings, _, err := e.RunWithStats(ctx, projectPath, rules...)
+
+	return findings, err
+}
+
+// RunWithStats does the exact same thing as Run, but also returns the stats of the analysis
+func (e *Engine) RunWithStats(ctx context.Context, projectPath string, rules ...Rule) ([]Finding, Stats, error) {
 	var findings []Finding
 
-	paths, err := e.getValidFilePaths(projectPath)
+	stats, err := e.run(ctx, projectPath, rules, func(newFindings []Finding) {
+		findings = append(findings, newFindings...)
+	})
+
+	return findings, stats, err
+}
+
+// RunStream does the exact same thing as Run, but instead of waiting the end of the analysis to return all findings,
+// it sends the findings of each file to the returned findings channel as soon as they are found. The findings channel
+// is closed when the analysis ends, and then the analysis error, or nil, is sent to the returned error channel.
+// The findings channel must be drained by the caller or the context cancelled, otherwise the analysis will never end.
+// When the context is cancelled, the findings not sent yet are dropped and the context error is returned
+func (e *Engine) RunStream(ctx context.Context, projectPath string, rules ...Rule) (<-chan Finding, <-chan error) {
+	findingsCh := make(chan Finding)
+	errCh := make(chan error, 1)
+
+	go func() {
+		_, err := e.run(ctx, projectPath, rules, func(newFindings []Finding) {
+			sendFindings(ctx, findingsCh, newFindings)
+		})
+
+		close(findingsCh)
+		errCh <- err
+		close(errCh)
+	}()
+
+	return findingsCh, errCh
+}
+
+// sendFindings sends each finding to the findings channel, stopping when the context is cancelled
+func sendFindings(ctx context.Context, findingsCh chan<- Finding, findings []Finding) {
+	for _, finding := range findings {
+		select {
+		case findingsCh <- finding:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run walks through projectPath and runs the rules for each file in a pool of goroutines, the findings of each file
+// are passed to the onFindings function, which is never called concurrently, so it doesn't need to be thread safe.
+// Rules that implement UnitRule are ran after all files, with all file paths at once
+// nolint:funlen,gocyclo // necessary complexity, breaking this function will lead to an even more complex code
+func (e *Engine) run(ctx context.Context, projectPath string, allRules []Rule,
+	onFindings func([]Finding)) (Stats, error) {
+	var stats Stats
+
+	filteredRules, err := e.filterRules(allRules)
+	if err != nil {
+		return stats, err
+	}
+
+	rules, unitRules := e.splitUnitRules(filteredRules)
+
+	paths, skippedPaths, err := e.getValidFilePaths(projectPath)
 	if err != nil {
-		return nil, err
+		return stats, err
 	}
 
+	root := projectRoot(projectPath)
+
+	stats.SkippedFiles = skippedPaths
+	deadline := e.scanDeadline()
+
 	mutex := new(sync.Mutex)
 	wg := sync.WaitGroup{}
 
 	workerPool, err := pool.NewPool(e.poolSize)
 	if err != nil {
-		return nil, err
+		return stats, err
 	}
 
 	defer workerPool.Release()
 
 	group, _ := errgroup.WithContext(ctx)
-
-	wg.Add(len(paths))
+	fileErrors := new(fileErrorsCollector)
 
 	for _, path := range paths {
+		if ctx.Err() != nil {
+			break
+		}
+
 		pathCopy := path
 
+		wg.Add(1)
+
 		errSubmit := workerPool.Submit(func() {
 			group.Go(func() error {
 				defer wg.Done()
 
-				newFindings, errRunRule := e.runRule(rules, pathCopy)
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				start := time.Now()
+
+				newFindings, truncated, errRunRule := e.runRule(rules, pathCopy, deadline)
+				elapsed := time.Since(start)
+				if errRunRule != nil && e.continueOnError {
+					return fileErrors.collect(pathCopy, errRunRule)
+				}
+
 				if errRunRule != nil {
 					return errRunRule
 				}
 
 				mutex.Lock()
-				findings = append(findings, newFindings...)
+				onFindings(e.processFindings(newFindings, root))
+				stats.MatchDuration += elapsed
+				if truncated {
+					stats.Truncated = true
+				} else {
+					stats.FilesScanned++
+					stats.RulesEvaluated += len(rules)
+				}
 				mutex.Unlock()
 
 				return errRunRule
 			})
 		})
 		if errSubmit != nil {
-			return nil, errSubmit
+			return stats, errSubmit
 		}
 	}
 
 	wg.Wait()
-	err = group.Wait()
 
-	return findings, err
+	if err = group.Wait(); err != nil {
+		return stats, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return stats, err
+	}
+
+	if err = e.runUnitRules(unitRules, paths, deadline, func(findings []Finding) {
+		onFindings(e.processFindings(findings, root))
+	}, &stats); err != nil {
+		return stats, err
+	}
+
+	return stats, fileErrors.err()
 }
 
-func (e *Engine) runRule(rules []Rule, pathCopy string) ([]Finding, error) {
-	var findings []Finding
+// processFindings sets the fields of the findings that are filled by the engine, like the relative filename, then
+// applies the engine configurations that change the findings found by the rules
+func (e *Engine) processFindings(findings []Finding, root string) []Finding {
+	setOccurrences(setRelativeFilenames(findings, root))
+
+	return e.overrideSeverities(e.filterChangedLines(findings))
+}
+
+// splitUnitRules separates the rules that should run for each file from the ones that implement UnitRule
+func (e *Engine) splitUnitRules(allRules []Rule) (rules []Rule, unitRules []UnitRule) {
+	for _, rule := range allRules {
+		if unitRule, ok := rule.(UnitRule); ok {
+			unitRules = append(unitRules, unitRule)
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, unitRules
+}
+
+// runUnitRules runs each unit rule with all file paths, passing the findings to the onFindings function, which should
+// process them. The rules left when the deadline is exceeded are not ran
+func (e *Engine) runUnitRules(unitRules []UnitRule, paths []string, deadline time.Time,
+	onFindings func([]Finding), stats *Stats) error {
+	for _, unitRule := range unitRules {
+		if isDeadlineExceeded(deadline) {
+			stats.Truncated = true
+
+			return nil
+		}
+
+		if err := e.runUnitRule(unitRule, paths, onFindings, stats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runUnitRule runs the unit rule with all file paths, passing its findings to the onFindings function and counting in
+// the stats how long it took to find them
+func (e *Engine) runUnitRule(unitRule UnitRule, paths []string, onFindings func([]Finding), stats *Stats) error {
+	start := time.Now()
+
+	findings, err := unitRule.RunUnit(paths)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		return err
+	}
+
+	onFindings(findings)
+	stats.RulesEvaluated++
+	stats.MatchDuration += elapsed
 
+	return nil
+}
+
+// runRule runs the rules in the file, if the deadline is exceeded the rules left are not ran and the findings found
+// until then are returned as truncated
+func (e *Engine) runRule(rules []Rule, pathCopy string, deadline time.Time) (findings []Finding, truncated bool,
+	err error) {
 	for _, rule := range rules {
+		if isDeadlineExceeded(deadline) {
+			return findings, true, nil
+		}
+
 		f, err := rule.Run(pathCopy)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		findings = append(findings, f...)
 	}
 
-	return findings, nil
+	return findings, false, nil
 }
 
 // getValidFilePaths this function will walk the project directory and will look for files that match the extensions
 // informed during the initialization of the engine and return a slice with it.
-// Directories, sys links and files with extensions that are not in Engine.extensions struct wil be ignored
-func (e *Engine) getValidFilePaths(projectPath string) ([]string, error) {
-	var validPaths []string
+// Directories, sys links and files with extensions that are not in Engine.extensions struct wil be ignored.
+// Files bigger than Engine.maxFileSize are also ignored, but they are returned in the skipped paths slice
+func (e *Engine) getValidFilePaths(projectPath string) ([]string, []string, error) {
+	var paths filePaths
 
 	err := filepath.WalkDir(projectPath, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil || e.isInvalidFilePath(path, entry) {
 			return err
 		}
 
-		validPaths = append(validPaths, path)
-
-		return nil
+		return e.addFilePath(&paths, path, entry)
 	})
 
-	return validPaths, err
+	return paths.valid, paths.skipped, err
+}
+
+// filePaths holds the paths found by getValidFilePaths
+type filePaths struct {
+	valid   []string
+	skipped []string
+}
+
+// addFilePath adds the path to the skipped paths if the file is too big, otherwise to the valid ones
+func (e *Engine) addFilePath(paths *filePaths, path string, entry fs.DirEntry) error {
+	isTooBig, err := e.isFileTooBig(entry)
+	if err != nil {
+		return err
+	}
+
+	if isTooBig {
+		paths.skipped = append(paths.skipped, path)
+	} else {
+		paths.valid = append(paths.valid, path)
+	}
+
+	return nil
+}
+
+// isFileTooBig check if the file size is greater than the max file size, always false if there is no limit set
+func (e *Engine) isFileTooBig(entry fs.DirEntry) (bool, error) {
+	if e.maxFileSize <= 0 {
+		return false, nil
+	}
+
+	info, err := entry.Info()
+	if err != nil {
+		return false, err
+	}
+
+	return info.Size() > e.maxFileSize, nil
 }
 
 // isInvalidFilePath contains a list of validations to check if a path needs to be analyzed. It will ignore directories,
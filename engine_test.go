@@ -17,8 +17,11 @@ package engine
 import (
 	"context"
 	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -123,3 +126,211 @@ func TestEngineRun(t *testing.T) {
 		})
 	}
 }
+
+// createProject creates a temporary project with the informed files, where the map key is the relative path of the
+// file and the value its content
+func createProject(t *testing.T, files map[string]string) string {
+	projectPath := t.TempDir()
+
+	for path, content := range files {
+		fullPath := filepath.Join(projectPath, path)
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), os.ModePerm))
+		assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0o600))
+	}
+
+	return projectPath
+}
+
+func TestEngineRunWithStats(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"main.go":        "package main",
+		"api/handler.go": "package api",
+		"api/server.go":  "package api",
+		"README.md":      "# readme",
+	})
+
+	t.Run("Should return stats of the analysis", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+
+		findings, stats, err := engine.RunWithStats(
+			context.Background(), projectPath, newRuleMock([]Finding{{}}, nil), newRuleMock(nil, nil),
+		)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 3)
+		assert.Equal(t, 3, stats.FilesScanned)
+		assert.Equal(t, 6, stats.RulesEvaluated)
+		assert.Greater(t, int64(stats.MatchDuration), int64(0))
+	})
+
+	t.Run("Should return empty stats when no file match the extensions", func(t *testing.T) {
+		engine := NewEngine(0, ".invalidExt")
+
+		findings, stats, err := engine.RunWithStats(context.Background(), projectPath, newRuleMock([]Finding{{}}, nil))
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+		assert.Equal(t, Stats{}, stats)
+	})
+}
+
+func TestEngineRunWithMaxFileSize(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"small.go": "package main",
+		"huge.go":  "package main\n\n// " + strings.Repeat("generated ", 1024),
+	})
+
+	t.Run("Should skip files bigger than the max file size", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetMaxFileSize(1024)
+
+		findings, stats, err := engine.RunWithStats(context.Background(), projectPath, newRuleMock([]Finding{{}}, nil))
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, 1, stats.FilesScanned)
+		assert.Equal(t, []string{filepath.Join(projectPath, "huge.go")}, stats.SkippedFiles)
+	})
+
+	t.Run("Should not skip any file when max file size is not set", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+
+		findings, stats, err := engine.RunWithStats(context.Background(), projectPath, newRuleMock([]Finding{{}}, nil))
+		assert.NoError(t, err)
+		assert.Len(t, findings, 2)
+		assert.Empty(t, stats.SkippedFiles)
+	})
+}
+
+func TestEngineRunStream(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"main.go":        "package main",
+		"api/handler.go": "package api",
+		"api/server.go":  "package api",
+	})
+
+	t.Run("Should receive all findings and close the channel", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+
+		findingsCh, errCh := engine.RunStream(context.Background(), projectPath, newRuleMock([]Finding{{}, {}}, nil))
+
+		var findings []Finding
+		for finding := range findingsCh {
+			findings = append(findings, finding)
+		}
+
+		assert.NoError(t, <-errCh)
+		assert.Len(t, findings, 6)
+
+		_, isOpen := <-findingsCh
+		assert.False(t, isOpen)
+	})
+
+	t.Run("Should close the channel and return error when failed to run rule", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+
+		findingsCh, errCh := engine.RunStream(
+			context.Background(), projectPath, newRuleMock(nil, errors.New("test error")),
+		)
+
+		for range findingsCh {
+			t.Fatal("should not receive findings")
+		}
+
+		assert.Error(t, <-errCh)
+	})
+
+	t.Run("Should stop and return the context error when cancelled mid-stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		findingsCh, errCh := NewEngine(0, ".go").RunStream(ctx, projectPath, newRuleMock([]Finding{{}, {}}, nil))
+
+		<-findingsCh
+		cancel()
+
+		select {
+		case err := <-errCh:
+			assert.True(t, errors.Is(err, context.Canceled))
+		case <-time.After(5 * time.Second):
+			t.Fatal("the analysis should stop when the context is cancelled")
+		}
+	})
+
+	t.Run("Should close the channel and return error when invalid project path", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+
+		findingsCh, errCh := engine.RunStream(context.Background(), "invalidPath", newRuleMock(nil, nil))
+
+		for range findingsCh {
+			t.Fatal("should not receive findings")
+		}
+
+		assert.Error(t, <-errCh)
+	})
+}
+
+func TestFindingFingerprint(t *testing.T) {
+	finding := Finding{
+		ID:             "HORUSEC-1",
+		CodeSample:     "exec(cmd)",
+		SourceLocation: Location{Filename: "main.go", Line: 10, Column: 2},
+	}
+
+	t.Run("Should return the same fingerprint when only the position changes", func(t *testing.T) {
+		moved := finding
+		moved.SourceLocation.Line, moved.SourceLocation.Column = 20, 4
+
+		assert.Equal(t, finding.Fingerprint(), moved.Fingerprint())
+	})
+
+	t.Run("Should return different fingerprints when rule, file or code sample changes", func(t *testing.T) {
+		otherRule, otherFile, otherSample := finding, finding, finding
+		otherRule.ID = "HORUSEC-2"
+		otherFile.SourceLocation.Filename = "other.go"
+		otherSample.CodeSample = "exec(other)"
+
+		assert.NotEqual(t, finding.Fingerprint(), otherRule.Fingerprint())
+		assert.NotEqual(t, finding.Fingerprint(), otherFile.Fingerprint())
+		assert.NotEqual(t, finding.Fingerprint(), otherSample.Fingerprint())
+	})
+
+	t.Run("Should return different fingerprints for identical lines of the same file", func(t *testing.T) {
+		projectPath := createProject(t, map[string]string{"main.go": "package main"})
+		mainPath := filepath.Join(projectPath, "main.go")
+
+		rule := newRuleMock([]Finding{
+			{ID: "HORUSEC-1", CodeSample: "exec(cmd)", SourceLocation: Location{Filename: mainPath, Line: 20}},
+			{ID: "HORUSEC-1", CodeSample: "exec(cmd)", SourceLocation: Location{Filename: mainPath, Line: 10}},
+		}, nil)
+
+		findings, err := NewEngine(0, ".go").Run(context.Background(), projectPath, rule)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 2)
+
+		assert.Equal(t, 1, findings[0].Occurrence)
+		assert.Equal(t, 0, findings[1].Occurrence)
+		assert.NotEqual(t, findings[0].Fingerprint(), findings[1].Fingerprint())
+	})
+
+	t.Run("Should return the same fingerprint in different checkouts of the project", func(t *testing.T) {
+		var fingerprints []string
+
+		for _, projectPath := range []string{t.TempDir(), t.TempDir()} {
+			assert.NoError(t, os.MkdirAll(filepath.Join(projectPath, "api"), 0o700))
+			path := filepath.Join(projectPath, "api", "main.go")
+			assert.NoError(t, os.WriteFile(path, []byte("package main"), 0o600))
+
+			rule := newRuleMock([]Finding{
+				{ID: "HORUSEC-1", CodeSample: "exec(cmd)", SourceLocation: Location{Filename: path, Line: 10}},
+			}, nil)
+
+			findings, err := NewEngine(0, ".go").Run(context.Background(), projectPath, rule)
+			assert.NoError(t, err)
+			assert.Len(t, findings, 1)
+			assert.Equal(t, "api/main.go", findings[0].SourceLocation.RelativeFilename)
+
+			fingerprints = append(fingerprints, findings[0].Fingerprint())
+		}
+
+		assert.Equal(t, fingerprints[0], fingerprints[1])
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// FileError represents an error that happened while running the rules in a single file
+type FileError struct {
+	Path string
+	Err  error
+}
+
+// Error returns the file path and the error message
+func (f *FileError) Error() string {
+	return fmt.Sprintf("%s: %v", f.Path, f.Err)
+}
+
+// Unwrap returns the original error of the file
+func (f *FileError) Unwrap() error {
+	return f.Err
+}
+
+// FileErrors holds the errors of each file that failed to be analyzed, it's returned by the engine when it's set to
+// continue on errors, so the findings of the other files are still returned
+type FileErrors []*FileError
+
+// Error returns the total of files that failed and the first error message
+func (f FileErrors) Error() string {
+	switch len(f) {
+	case 0:
+		return "no files failed to be analyzed"
+	case 1:
+		return f[0].Error()
+	default:
+		return fmt.Sprintf("failed to analyze %d files, first error: %v", len(f), f[0])
+	}
+}
+
+// SetContinueOnError sets if the engine should keep analyzing the other files when the rules return an error for a
+// file. When true, the engine returns all findings and a FileErrors error with the errors of each file, otherwise
+// the analysis is stopped at the first error, which is the default. Errors of unit rules always stop the analysis
+func (e *Engine) SetContinueOnError(continueOnError bool) {
+	e.continueOnError = continueOnError
+}
+
+// fileErrorsCollector collects the file errors of the goroutines of the engine pool
+type fileErrorsCollector struct {
+	mutex  sync.Mutex
+	errors FileErrors
+}
+
+// collect stores the error of the file, returning nil so the analysis can continue
+func (c *fileErrorsCollector) collect(path string, err error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.errors = append(c.errors, &FileError{Path: path, Err: err})
+
+	return nil
+}
+
+// err returns the collected errors sorted by path, or nil if there is none
+func (c *fileErrorsCollector) err() error {
+	if len(c.errors) == 0 {
+		return nil
+	}
+
+	sort.Slice(c.errors, func(i, j int) bool {
+		return c.errors[i].Path < c.errors[j].Path
+	})
+
+	return c.errors
+}
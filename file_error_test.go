@@ -0,0 +1,99 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pathRuleMock returns an error for the paths in the errors map and a finding for the other ones
+type pathRuleMock struct {
+	errors map[string]error
+}
+
+func (r *pathRuleMock) Run(path string) ([]Finding, error) {
+	if err, ok := r.errors[filepath.Base(path)]; ok {
+		return nil, err
+	}
+
+	return []Finding{{SourceLocation: Location{Filename: path}}}, nil
+}
+
+func TestEngineRunWithContinueOnError(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"a.go": "package main",
+		"b.go": "package main",
+		"c.go": "package main",
+		"d.go": "package main",
+	})
+
+	errInvalidFile := errors.New("invalid file")
+	rule := &pathRuleMock{errors: map[string]error{"d.go": errInvalidFile, "b.go": errInvalidFile}}
+
+	t.Run("Should return findings of valid files and the errors of the other ones", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetContinueOnError(true)
+
+		findings, stats, err := engine.RunWithStats(context.Background(), projectPath, rule)
+		assert.Len(t, findings, 2)
+		assert.Equal(t, 2, stats.FilesScanned)
+
+		var fileErrors FileErrors
+		assert.True(t, errors.As(err, &fileErrors))
+		assert.Len(t, fileErrors, 2)
+		assert.Equal(t, filepath.Join(projectPath, "b.go"), fileErrors[0].Path)
+		assert.Equal(t, filepath.Join(projectPath, "d.go"), fileErrors[1].Path)
+		assert.True(t, errors.Is(fileErrors[0], errInvalidFile))
+		assert.EqualError(t, err, "failed to analyze 2 files, first error: "+
+			filepath.Join(projectPath, "b.go")+": invalid file")
+	})
+
+	t.Run("Should return nil error when all files are valid", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetContinueOnError(true)
+
+		findings, err := engine.Run(context.Background(), projectPath, &pathRuleMock{})
+		assert.NoError(t, err)
+		assert.Len(t, findings, 4)
+	})
+
+	t.Run("Should stop at the first error by default", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+
+		_, err := engine.Run(context.Background(), projectPath, rule)
+		assert.True(t, errors.Is(err, errInvalidFile))
+
+		var fileErrors FileErrors
+		assert.False(t, errors.As(err, &fileErrors))
+	})
+}
+
+func TestFileErrorsError(t *testing.T) {
+	errInvalidFile := errors.New("invalid file")
+
+	t.Run("Should not panic when there is no error", func(t *testing.T) {
+		assert.EqualError(t, FileErrors{}, "no files failed to be analyzed")
+		assert.EqualError(t, FileErrors(nil), "no files failed to be analyzed")
+	})
+
+	t.Run("Should return the error of the file when there is only one", func(t *testing.T) {
+		assert.EqualError(t, FileErrors{{Path: "a.go", Err: errInvalidFile}}, "a.go: invalid file")
+	})
+}
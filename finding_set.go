@@ -0,0 +1,103 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FindingSet aggregates the findings of many analyses, like the ones of each directory or language of a project,
+// removing duplicated findings. Two findings are duplicated when they have the same rule id, location, code sample and
+// occurrence
+type FindingSet struct {
+	findings map[string]Finding
+}
+
+// NewFindingSet creates a new finding set with the informed findings
+func NewFindingSet(findings ...Finding) *FindingSet {
+	set := &FindingSet{
+		findings: make(map[string]Finding, len(findings)),
+	}
+
+	set.Add(findings...)
+
+	return set
+}
+
+// Add adds the findings to the set, ignoring the ones that are already in it
+func (s *FindingSet) Add(findings ...Finding) {
+	for index := range findings {
+		key := s.key(&findings[index])
+
+		if _, ok := s.findings[key]; !ok {
+			s.findings[key] = findings[index]
+		}
+	}
+}
+
+// Merge adds all findings of the other set to this one
+func (s *FindingSet) Merge(other *FindingSet) {
+	for key, finding := range other.findings {
+		if _, ok := s.findings[key]; !ok {
+			s.findings[key] = finding
+		}
+	}
+}
+
+// Len returns the total of findings in the set
+func (s *FindingSet) Len() int {
+	return len(s.findings)
+}
+
+// Findings returns the findings of the set sorted by file, line, column, rule id and occurrence
+func (s *FindingSet) Findings() []Finding {
+	findings := make([]Finding, 0, len(s.findings))
+	for _, finding := range s.findings {
+		findings = append(findings, finding)
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return s.isBefore(&findings[i], &findings[j])
+	})
+
+	return findings
+}
+
+// isBefore checks if the first finding comes before the second one by file, line, column, rule id and occurrence
+func (s *FindingSet) isBefore(first, second *Finding) bool {
+	firstLocation, secondLocation := first.SourceLocation, second.SourceLocation
+
+	switch {
+	case firstLocation.Filename != secondLocation.Filename:
+		return firstLocation.Filename < secondLocation.Filename
+	case firstLocation.isBefore(secondLocation) || secondLocation.isBefore(firstLocation):
+		return firstLocation.isBefore(secondLocation)
+	case first.ID != second.ID:
+		return first.ID < second.ID
+	default:
+		return first.Occurrence < second.Occurrence
+	}
+}
+
+// key identifies a finding by its rule id, location, code sample and occurrence, the same identity of Fingerprint, so
+// findings without position like the NotMatch ones are not merged. The location is in the default base so the same
+// position in different bases has the same key
+func (s *FindingSet) key(finding *Finding) string {
+	location := finding.SourceLocation.WithBase(DefaultBase)
+
+	return fmt.Sprintf("%s\x00%s\x00%d\x00%d\x00%s\x00%d", finding.ID, location.Filename, location.Line,
+		location.Column, finding.CodeSample, finding.Occurrence)
+}
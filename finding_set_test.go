@@ -0,0 +1,84 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingSet(t *testing.T) {
+	newFinding := func(id, filename string, line, column int) Finding {
+		return Finding{ID: id, SourceLocation: Location{Filename: filename, Line: line, Column: column}}
+	}
+
+	t.Run("Should merge findings of two analyses removing duplicates", func(t *testing.T) {
+		apiSet := NewFindingSet(
+			newFinding("HS-2", "api/server.go", 10, 2),
+			newFinding("HS-1", "api/server.go", 10, 2),
+			newFinding("HS-1", "api/handler.go", 3, 0),
+		)
+
+		webSet := NewFindingSet(
+			newFinding("HS-1", "web/index.js", 1, 5),
+			newFinding("HS-1", "api/handler.go", 3, 0),
+		)
+
+		apiSet.Merge(webSet)
+
+		assert.Equal(t, 4, apiSet.Len())
+		assert.Equal(t, []Finding{
+			newFinding("HS-1", "api/handler.go", 3, 0),
+			newFinding("HS-1", "api/server.go", 10, 2),
+			newFinding("HS-2", "api/server.go", 10, 2),
+			newFinding("HS-1", "web/index.js", 1, 5),
+		}, apiSet.Findings())
+		assert.Equal(t, 2, webSet.Len())
+	})
+
+	t.Run("Should add findings sorting by line and column", func(t *testing.T) {
+		set := NewFindingSet()
+		set.Add(
+			newFinding("HS-1", "main.go", 10, 5),
+			newFinding("HS-1", "main.go", 10, 1),
+			newFinding("HS-1", "main.go", 2, 8),
+			newFinding("HS-1", "main.go", 10, 1),
+		)
+
+		assert.Equal(t, []Finding{
+			newFinding("HS-1", "main.go", 2, 8),
+			newFinding("HS-1", "main.go", 10, 1),
+			newFinding("HS-1", "main.go", 10, 5),
+		}, set.Findings())
+	})
+
+	t.Run("Should keep the findings in the same location with different occurrences", func(t *testing.T) {
+		first, second := newFinding("HS-1", "main.go", 0, 0), newFinding("HS-1", "main.go", 0, 0)
+		second.Occurrence = 1
+
+		set := NewFindingSet(second, first, first)
+
+		assert.Equal(t, 2, set.Len())
+		assert.Equal(t, []Finding{first, second}, set.Findings())
+	})
+
+	t.Run("Should return empty findings when set is empty", func(t *testing.T) {
+		set := NewFindingSet()
+
+		assert.Equal(t, 0, set.Len())
+		assert.Empty(t, set.Findings())
+	})
+}
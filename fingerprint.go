@@ -0,0 +1,87 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// identityKey identifies the vulnerable code of a finding regardless of its position, using the rule id, the file path
+// relative to the project, when known, and the code sample
+func (f *Finding) identityKey() string {
+	filename := f.SourceLocation.RelativeFilename
+	if filename == "" {
+		filename = filepath.ToSlash(f.SourceLocation.Filename)
+	}
+
+	return strings.Join([]string{f.ID, filename, strings.TrimSpace(f.CodeSample)}, "\x00")
+}
+
+// projectRoot returns the directory that the findings paths are relative to, which is the project path itself or its
+// directory when the project path is a single file
+func projectRoot(projectPath string) string {
+	if info, err := os.Stat(projectPath); err == nil && !info.IsDir() {
+		return filepath.Dir(projectPath)
+	}
+
+	return projectPath
+}
+
+// setRelativeFilenames sets the path of the file of each finding relative to the project root. Paths that can't be
+// made relative are kept as they are
+func setRelativeFilenames(findings []Finding, root string) []Finding {
+	for index := range findings {
+		location := &findings[index].SourceLocation
+
+		relative, err := filepath.Rel(root, location.Filename)
+		if err != nil {
+			relative = location.Filename
+		}
+
+		location.RelativeFilename = filepath.ToSlash(relative)
+	}
+
+	return findings
+}
+
+// setOccurrences sets the occurrence of each finding, counting the previous findings in the file, by line and column,
+// with the same identity key
+func setOccurrences(findings []Finding) []Finding {
+	for _, indexes := range indexesByIdentity(findings) {
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return findings[indexes[i]].SourceLocation.isBefore(findings[indexes[j]].SourceLocation)
+		})
+
+		for occurrence, index := range indexes {
+			findings[index].Occurrence = occurrence
+		}
+	}
+
+	return findings
+}
+
+// indexesByIdentity groups the indexes of the findings by their identity key
+func indexesByIdentity(findings []Finding) map[string][]int {
+	indexesByKey := make(map[string][]int)
+	for index := range findings {
+		key := findings[index].identityKey()
+		indexesByKey[key] = append(indexesByKey[key], index)
+	}
+
+	return indexesByKey
+}
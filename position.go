@@ -0,0 +1,133 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import "fmt"
+
+// PositionBase represents from which number the lines and columns of a location are counted
+type PositionBase int
+
+const (
+	// DefaultBase counts the lines from 1 and the columns from 0, which is what the engine always reported. Locations
+	// in this base with line 0 have no position, like the NotMatch findings. It's the default
+	DefaultBase PositionBase = iota
+
+	// ZeroBased counts both the lines and the columns from 0
+	ZeroBased
+
+	// OneBased counts both the lines and the columns from 1, which is what most editors display
+	OneBased
+)
+
+// WithBase returns the location with the line and column converted to the base. Locations without a position are
+// returned as they are, so they keep having line 0
+func (l Location) WithBase(base PositionBase) Location {
+	if l.Base == base || (l.Base == DefaultBase && l.Line <= 0) {
+		return l
+	}
+
+	return l.toDefaultBase().fromDefaultBase(base)
+}
+
+// toDefaultBase converts the line and column from the location base to DefaultBase
+func (l Location) toDefaultBase() Location {
+	switch l.Base {
+	case ZeroBased:
+		l.Line++
+	case OneBased:
+		l.Column--
+	}
+
+	l.Base = DefaultBase
+
+	return l
+}
+
+// fromDefaultBase converts the line and column from DefaultBase to the base
+func (l Location) fromDefaultBase(base PositionBase) Location {
+	switch base {
+	case ZeroBased:
+		l.Line--
+	case OneBased:
+		l.Column++
+	}
+
+	l.Base = base
+
+	return l
+}
+
+// isBefore checks if the location is in a line, or column of the same line, before the other one, in any base
+func (l Location) isBefore(other Location) bool {
+	first, second := l.WithBase(DefaultBase), other.WithBase(DefaultBase)
+	if first.Line != second.Line {
+		return first.Line < second.Line
+	}
+
+	return first.Column < second.Column
+}
+
+// PositionFormatter converts the position of a finding, in any base, into the convention expected by a consumer of
+// the findings
+type PositionFormatter interface {
+	FormatPosition(location Location) string
+}
+
+// displayFilename returns the file path relative to the project when it was set by the engine, otherwise the path of
+// the file as it was found
+func (l Location) displayFilename() string {
+	if l.RelativeFilename != "" {
+		return l.RelativeFilename
+	}
+
+	return l.Filename
+}
+
+// VSCodePositionFormatter formats positions as "file:line:column", both 1-based, the format understood by VS Code and
+// most editors to open a file in a position. The file path is relative to the project when it's known
+type VSCodePositionFormatter struct{}
+
+// FormatPosition returns the location as "file:line:column" converted to be 1-based
+func (VSCodePositionFormatter) FormatPosition(location Location) string {
+	location = location.WithBase(OneBased)
+
+	return fmt.Sprintf("%s:%d:%d", location.displayFilename(), location.Line, location.Column)
+}
+
+// GitHubPositionFormatter formats positions as "file#Lline", the anchor used by GitHub to highlight a line of a file.
+// GitHub anchors don't support columns, so it's ignored. The file path is relative to the project when it's known, as
+// the anchors require paths relative to the repository
+type GitHubPositionFormatter struct{}
+
+// FormatPosition returns the location as "file#Lline"
+func (GitHubPositionFormatter) FormatPosition(location Location) string {
+	location = location.WithBase(OneBased)
+
+	return fmt.Sprintf("%s#L%d", location.displayFilename(), location.Line)
+}
+
+// RawOffsetPositionFormatter formats positions as "file:#offset", with the 0-based byte offset of the vulnerable code,
+// the same format used by Go tools that accept offsets, like gopls
+type RawOffsetPositionFormatter struct{}
+
+// FormatPosition returns the location as "file:#offset"
+func (RawOffsetPositionFormatter) FormatPosition(location Location) string {
+	return fmt.Sprintf("%s:#%d", location.Filename, location.Offset)
+}
+
+// FormatPosition returns the source location of the finding converted by the formatter
+func (f *Finding) FormatPosition(formatter PositionFormatter) string {
+	return formatter.FormatPosition(f.SourceLocation)
+}
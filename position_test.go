@@ -0,0 +1,134 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// positionRuleMock returns the finding with the path of the analyzed file
+type positionRuleMock struct {
+	finding Finding
+}
+
+func (r *positionRuleMock) Run(path string) ([]Finding, error) {
+	finding := r.finding
+	finding.SourceLocation.Filename = path
+
+	return []Finding{finding}, nil
+}
+
+func TestFindingFormatPosition(t *testing.T) {
+	finding := Finding{
+		ID:             "HS-1",
+		SourceLocation: Location{Filename: "src/main.go", Line: 3, Column: 4, Offset: 42},
+	}
+
+	testCases := []struct {
+		name      string
+		formatter PositionFormatter
+		expected  string
+	}{
+		{
+			name:      "Should format with 1-based line and column to VS Code",
+			formatter: VSCodePositionFormatter{},
+			expected:  "src/main.go:3:5",
+		},
+		{
+			name:      "Should format with a line anchor to GitHub",
+			formatter: GitHubPositionFormatter{},
+			expected:  "src/main.go#L3",
+		},
+		{
+			name:      "Should format with the 0-based byte offset",
+			formatter: RawOffsetPositionFormatter{},
+			expected:  "src/main.go:#42",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, finding.FormatPosition(testCase.formatter))
+		})
+	}
+}
+
+func TestFindingFormatPositionRelativeFilename(t *testing.T) {
+	projectPath := createProject(t, map[string]string{"src/a.go": "package src"})
+
+	rule := &positionRuleMock{finding: Finding{ID: "HS-1", SourceLocation: Location{Line: 2, Column: 4, Offset: 9}}}
+
+	findings, err := NewEngine(0, ".go").Run(context.Background(), projectPath, rule)
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1)
+
+	assert.Equal(t, "src/a.go:2:5", findings[0].FormatPosition(VSCodePositionFormatter{}))
+	assert.Equal(t, "src/a.go#L2", findings[0].FormatPosition(GitHubPositionFormatter{}))
+	assert.Equal(t, filepath.Join(projectPath, "src", "a.go")+":#9",
+		findings[0].FormatPosition(RawOffsetPositionFormatter{}))
+}
+
+func TestFindingFormatPositionWithBase(t *testing.T) {
+	testCases := []struct {
+		name     string
+		location Location
+	}{
+		{
+			name:     "Should format a location in the default base",
+			location: Location{Filename: "b.go", Line: 1, Column: 2},
+		},
+		{
+			name:     "Should format a location in the zero based base",
+			location: Location{Filename: "b.go", Line: 0, Column: 2, Base: ZeroBased},
+		},
+		{
+			name:     "Should format a location in the one based base",
+			location: Location{Filename: "b.go", Line: 1, Column: 3, Base: OneBased},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			finding := Finding{SourceLocation: testCase.location}
+
+			assert.Equal(t, "b.go:1:3", finding.FormatPosition(VSCodePositionFormatter{}))
+			assert.Equal(t, "b.go#L1", finding.FormatPosition(GitHubPositionFormatter{}))
+		})
+	}
+}
+
+func TestLocationWithBase(t *testing.T) {
+	location := Location{Filename: "b.go", Line: 4, Column: 2}
+
+	t.Run("Should convert between all bases", func(t *testing.T) {
+		zeroBased := location.WithBase(ZeroBased)
+		assert.Equal(t, Location{Filename: "b.go", Line: 3, Column: 2, Base: ZeroBased}, zeroBased)
+
+		oneBased := zeroBased.WithBase(OneBased)
+		assert.Equal(t, Location{Filename: "b.go", Line: 4, Column: 3, Base: OneBased}, oneBased)
+
+		assert.Equal(t, location, oneBased.WithBase(DefaultBase))
+	})
+
+	t.Run("Should keep locations without a position", func(t *testing.T) {
+		noPosition := Location{Filename: "b.go"}
+
+		assert.Equal(t, noPosition, noPosition.WithBase(OneBased))
+	})
+}
@@ -19,6 +19,28 @@ type Rule interface {
 	Run(path string) ([]Finding, error)
 }
 
+// UnitRule defines a rule that needs all files of the analysis together, like the ones correlating the content of
+// different files. When a rule passed to the engine implements it, RunUnit is called a single time with all file paths
+// instead of calling Rule.Run for each file
+type UnitRule interface {
+	Rule
+	RunUnit(paths []string) ([]Finding, error)
+}
+
+// IdentifiedRule defines a rule that can be identified by an id, which allows the engine to filter it
+type IdentifiedRule interface {
+	Rule
+	RuleID() string
+}
+
+// GroupRule defines a rule that runs a group of rules together, like reading each file a single time for all of them.
+// The engine filters the rules of the group by their ids the same way as the rules informed directly
+type GroupRule interface {
+	Rule
+	// FilterRules returns a rule with only the rules of the group accepted by isAllowed, or nil if none is accepted
+	FilterRules(isAllowed func(rule Rule) bool) Rule
+}
+
 // Metadata holds information for the rule to match a useful advisory
 type Metadata struct {
 	ID            string
@@ -32,4 +54,10 @@ type Metadata struct {
 	Reference     string
 	SafeExample   string
 	UnsafeExample string
+	Tags          []string
+}
+
+// RuleID returns the id of the rule that holds the metadata, so any rule embedding it implements IdentifiedRule
+func (m *Metadata) RuleID() string {
+	return m.ID
 }
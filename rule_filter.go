@@ -0,0 +1,115 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnidentifiedRule is returned by the analysis when there are allowed rule ids and a rule doesn't have an id, so
+// a wrong allowed ids list is reported instead of silently running no rules
+var ErrUnidentifiedRule = errors.New("rule without id can't be filtered by the allowed rule ids")
+
+// ruleFilter holds the rule ids that should or shouldn't be ran by the engine
+type ruleFilter struct {
+	allowed map[string]bool
+	denied  map[string]bool
+}
+
+// SetRuleFilter restricts which rules are ran by the engine using their ids, which allows disabling noisy rules without
+// changing them. If allowed is not empty, only the rules with those ids are ran, and the rules with ids in denied are
+// never ran. Rules that don't implement IdentifiedRule, or that have an empty id, can't be filtered by denied, and
+// the analysis returns ErrUnidentifiedRule when there are allowed ids, since it can't tell if they should be ran.
+// The rules of a GroupRule are filtered one by one
+func (e *Engine) SetRuleFilter(allowed, denied []string) {
+	e.ruleFilter = ruleFilter{
+		allowed: toSet(allowed),
+		denied:  toSet(denied),
+	}
+}
+
+// filterRules returns only the rules that should be ran according to the rule filter, or ErrUnidentifiedRule if there
+// are allowed ids and any rule, including the ones of a group, doesn't have an id
+func (e *Engine) filterRules(rules []Rule) ([]Rule, error) {
+	filtered := make([]Rule, 0, len(rules))
+
+	var err error
+
+	isAllowed := e.ruleFilter.isAllowedKeepingError(&err)
+
+	for _, rule := range rules {
+		if filteredRule := filterRule(rule, isAllowed); filteredRule != nil {
+			filtered = append(filtered, filteredRule)
+		}
+	}
+
+	return filtered, err
+}
+
+// filterRule returns the rule if it's allowed, or the allowed rules of a group, or nil if none of them is allowed
+func filterRule(rule Rule, isAllowed func(rule Rule) bool) Rule {
+	if group, ok := rule.(GroupRule); ok {
+		return group.FilterRules(isAllowed)
+	}
+
+	if isAllowed(rule) {
+		return rule
+	}
+
+	return nil
+}
+
+// isAllowedKeepingError returns a function that checks if the rule is allowed, keeping in err the first error found,
+// so it can be informed to GroupRule.FilterRules
+func (f *ruleFilter) isAllowedKeepingError(err *error) func(rule Rule) bool {
+	return func(rule Rule) bool {
+		allowed, errAllowed := f.isAllowed(rule)
+		if errAllowed != nil && *err == nil {
+			*err = errAllowed
+		}
+
+		return allowed
+	}
+}
+
+// isAllowed checks if the rule id is allowed and not denied, returning ErrUnidentifiedRule if the rule doesn't have an
+// id and there are allowed ids
+func (f *ruleFilter) isAllowed(rule Rule) (bool, error) {
+	id := ""
+	if identifiedRule, ok := rule.(IdentifiedRule); ok {
+		id = identifiedRule.RuleID()
+	}
+
+	if id == "" && len(f.allowed) > 0 {
+		return false, fmt.Errorf("%w: %T", ErrUnidentifiedRule, rule)
+	}
+
+	return (len(f.allowed) == 0 || f.allowed[id]) && !f.denied[id], nil
+}
+
+// toSet creates a set with the values, returning nil if there are no values
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
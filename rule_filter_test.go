@@ -0,0 +1,142 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type identifiedRuleMock struct {
+	Metadata
+	calls int
+}
+
+func (r *identifiedRuleMock) Run(_ string) ([]Finding, error) {
+	r.calls++
+
+	return []Finding{{ID: r.ID}}, nil
+}
+
+func TestEngineRunWithRuleFilter(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"main.go": "package main",
+	})
+
+	testCases := []struct {
+		name          string
+		allowed       []string
+		denied        []string
+		expectedIDs   []string
+		expectedCalls []int
+	}{
+		{
+			name:          "Should run all rules when filter is empty",
+			expectedIDs:   []string{"", "HS-1", "HS-2", "HS-3"},
+			expectedCalls: []int{1, 1, 1},
+		},
+		{
+			name:          "Should not run denied rules",
+			denied:        []string{"HS-2"},
+			expectedIDs:   []string{"", "HS-1", "HS-3"},
+			expectedCalls: []int{1, 0, 1},
+		},
+		{
+			name:          "Should run only allowed rules",
+			allowed:       []string{"HS-1", "HS-2"},
+			expectedIDs:   []string{"HS-1", "HS-2"},
+			expectedCalls: []int{1, 1, 0},
+		},
+		{
+			name:          "Should not run rules that are allowed and denied",
+			allowed:       []string{"HS-1", "HS-2"},
+			denied:        []string{"HS-1"},
+			expectedIDs:   []string{"HS-2"},
+			expectedCalls: []int{0, 1, 0},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			identifiedRules := []*identifiedRuleMock{
+				{Metadata: Metadata{ID: "HS-1"}},
+				{Metadata: Metadata{ID: "HS-2"}},
+				{Metadata: Metadata{ID: "HS-3"}},
+			}
+
+			var rules []Rule
+			if len(testCase.allowed) == 0 {
+				rules = append(rules, newRuleMock([]Finding{{}}, nil))
+			}
+
+			for _, rule := range identifiedRules {
+				rules = append(rules, rule)
+			}
+
+			engine := NewEngine(0, ".go")
+			engine.SetRuleFilter(testCase.allowed, testCase.denied)
+
+			findings, err := engine.Run(context.Background(), projectPath, rules...)
+			assert.NoError(t, err)
+
+			var ids []string
+			for _, finding := range findings {
+				ids = append(ids, finding.ID)
+			}
+
+			var calls []int
+			for _, rule := range identifiedRules {
+				calls = append(calls, rule.calls)
+			}
+
+			assert.Equal(t, testCase.expectedIDs, ids)
+			assert.Equal(t, testCase.expectedCalls, calls)
+		})
+	}
+
+	t.Run("Should return error when there are allowed ids and a rule doesn't have an id", func(t *testing.T) {
+		rules := []Rule{
+			&identifiedRuleMock{Metadata: Metadata{ID: "HS-1"}},
+			newRuleMock([]Finding{{}}, nil),
+		}
+
+		engine := NewEngine(0, ".go")
+		engine.SetRuleFilter([]string{"HS-1"}, nil)
+
+		findings, err := engine.Run(context.Background(), projectPath, rules...)
+		assert.True(t, errors.Is(err, ErrUnidentifiedRule))
+		assert.Empty(t, findings)
+	})
+
+	t.Run("Should return error when there are allowed ids and a rule has an empty id", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetRuleFilter([]string{"HS-1"}, nil)
+
+		_, err := engine.Run(context.Background(), projectPath, &identifiedRuleMock{})
+		assert.True(t, errors.Is(err, ErrUnidentifiedRule))
+	})
+
+	t.Run("Should run rules without id when only denied ids are set", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetRuleFilter(nil, []string{"HS-1"})
+
+		findings, err := engine.Run(context.Background(), projectPath, newRuleMock([]Finding{{}}, nil))
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+	})
+}
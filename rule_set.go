@@ -0,0 +1,136 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import "context"
+
+// RuleSetMetadata holds the information shared by all rules of a rule set
+type RuleSetMetadata struct {
+	Name    string
+	Version string
+}
+
+// RuleSet represents a group of rules that are enabled together, like themed packs of rules (e.g. OWASP Top 10)
+type RuleSet struct {
+	RuleSetMetadata
+	Rules []Rule
+}
+
+// NewRuleSet creates a new rule set with the rules
+func NewRuleSet(name, version string, rules ...Rule) *RuleSet {
+	return &RuleSet{
+		RuleSetMetadata: RuleSetMetadata{Name: name, Version: version},
+		Rules:           rules,
+	}
+}
+
+// RunRuleSets does the exact same thing as Run with all rules of the rule sets, but the findings are tagged with the
+// metadata of the set of the rule that found them in Finding.RuleSet
+func (e *Engine) RunRuleSets(ctx context.Context, projectPath string, sets ...*RuleSet) ([]Finding, error) {
+	var rules []Rule
+
+	for _, set := range sets {
+		rules = append(rules, set.taggedRules()...)
+	}
+
+	return e.Run(ctx, projectPath, rules...)
+}
+
+// taggedRules wraps each rule of the set so their findings are tagged with the set metadata, the wrapped rules keep
+// being unit or group rules if the original ones are
+func (s *RuleSet) taggedRules() []Rule {
+	rules := make([]Rule, 0, len(s.Rules))
+
+	for _, rule := range s.Rules {
+		rules = append(rules, tagRule(rule, s.RuleSetMetadata))
+	}
+
+	return rules
+}
+
+// tagRule wraps the rule so its findings are tagged with the set metadata, keeping it a unit or group rule if it is
+func tagRule(rule Rule, metadata RuleSetMetadata) Rule {
+	tagged := &ruleSetRule{Rule: rule, metadata: metadata}
+
+	switch typedRule := rule.(type) {
+	case UnitRule:
+		return &ruleSetUnitRule{ruleSetRule: tagged, unitRule: typedRule}
+	case GroupRule:
+		return &ruleSetGroupRule{ruleSetRule: tagged, groupRule: typedRule}
+	default:
+		return tagged
+	}
+}
+
+// ruleSetRule wraps a rule of a rule set, tagging its findings with the set metadata
+type ruleSetRule struct {
+	Rule
+	metadata RuleSetMetadata
+}
+
+// Run runs the wrapped rule and tags its findings
+func (r *ruleSetRule) Run(path string) ([]Finding, error) {
+	findings, err := r.Rule.Run(path)
+
+	return r.tag(findings), err
+}
+
+// RuleID returns the id of the wrapped rule, so it can still be filtered by the engine. Rules without an id return an
+// empty id, which is filtered the same way as rules that don't implement IdentifiedRule
+func (r *ruleSetRule) RuleID() string {
+	if identifiedRule, ok := r.Rule.(IdentifiedRule); ok {
+		return identifiedRule.RuleID()
+	}
+
+	return ""
+}
+
+// tag sets the rule set metadata in each finding
+func (r *ruleSetRule) tag(findings []Finding) []Finding {
+	for index := range findings {
+		findings[index].RuleSet = r.metadata
+	}
+
+	return findings
+}
+
+// ruleSetUnitRule wraps a unit rule of a rule set, tagging its findings with the set metadata
+type ruleSetUnitRule struct {
+	*ruleSetRule
+	unitRule UnitRule
+}
+
+// RunUnit runs the wrapped unit rule and tags its findings
+func (r *ruleSetUnitRule) RunUnit(paths []string) ([]Finding, error) {
+	findings, err := r.unitRule.RunUnit(paths)
+
+	return r.tag(findings), err
+}
+
+// ruleSetGroupRule wraps a group rule of a rule set, so the rules of the group can still be filtered
+type ruleSetGroupRule struct {
+	*ruleSetRule
+	groupRule GroupRule
+}
+
+// FilterRules filters the rules of the wrapped group, keeping the filtered group tagged with the set metadata
+func (r *ruleSetGroupRule) FilterRules(isAllowed func(rule Rule) bool) Rule {
+	filtered := r.groupRule.FilterRules(isAllowed)
+	if filtered == nil {
+		return nil
+	}
+
+	return tagRule(filtered, r.metadata)
+}
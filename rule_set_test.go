@@ -0,0 +1,96 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ruleSetRuleMock represents a rule returning a single finding with its id for each file
+type ruleSetRuleMock struct {
+	Metadata
+}
+
+func (r *ruleSetRuleMock) Run(_ string) ([]Finding, error) {
+	return []Finding{{ID: r.ID}}, nil
+}
+
+// unitRuleMock represents a unit rule returning a single finding with the total of paths as the code sample
+type unitRuleMock struct {
+	ruleSetRuleMock
+}
+
+func (r *unitRuleMock) RunUnit(paths []string) ([]Finding, error) {
+	return []Finding{{ID: r.ID, CodeSample: strconv.Itoa(len(paths))}}, nil
+}
+
+func TestEngineRunRuleSets(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"main.go":  "package main",
+		"utils.go": "package main",
+	})
+
+	owasp := NewRuleSet("owasp-top-10", "1.0.0",
+		&ruleSetRuleMock{Metadata: Metadata{ID: "HS-1"}},
+		&unitRuleMock{ruleSetRuleMock{Metadata: Metadata{ID: "HS-2"}}},
+	)
+	cwe := NewRuleSet("cwe-top-25", "2.1.0", &ruleSetRuleMock{Metadata: Metadata{ID: "HS-3"}})
+
+	t.Run("Should tag the findings with the metadata of the rule set", func(t *testing.T) {
+		findings, err := NewEngine(0, ".go").RunRuleSets(context.Background(), projectPath, owasp, cwe)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 5)
+
+		sort.Slice(findings, func(i, j int) bool {
+			return findings[i].ID < findings[j].ID
+		})
+
+		for _, finding := range findings[:3] {
+			assert.Equal(t, RuleSetMetadata{Name: "owasp-top-10", Version: "1.0.0"}, finding.RuleSet)
+		}
+
+		assert.Equal(t, "HS-2", findings[2].ID)
+		assert.Equal(t, "2", findings[2].CodeSample)
+
+		for _, finding := range findings[3:] {
+			assert.Equal(t, RuleSetMetadata{Name: "cwe-top-25", Version: "2.1.0"}, finding.RuleSet)
+		}
+	})
+
+	t.Run("Should keep filtering the rules of the set by id", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetRuleFilter(nil, []string{"HS-1", "HS-2"})
+
+		findings, err := engine.RunRuleSets(context.Background(), projectPath, owasp, cwe)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 2)
+
+		for _, finding := range findings {
+			assert.Equal(t, "HS-3", finding.ID)
+		}
+	})
+
+	t.Run("Should not tag the findings of rules ran outside a set", func(t *testing.T) {
+		findings, err := NewEngine(0, ".go").Run(context.Background(), projectPath, owasp.Rules[0])
+		assert.NoError(t, err)
+		assert.Len(t, findings, 2)
+		assert.Empty(t, findings[0].RuleSet)
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import "time"
+
+// SetScanBudget sets the max total duration of an analysis. When it's exceeded the workers stop running the rules
+// left, between files and rules, and the partial findings are returned with Stats.Truncated set. Rules already running
+// are not interrupted. Zero or lower means that there is no budget, which is the default
+func (e *Engine) SetScanBudget(budget time.Duration) {
+	e.scanBudget = budget
+}
+
+// scanDeadline returns the time when the scan budget of an analysis starting now is exceeded, or the zero time if
+// there is no budget
+func (e *Engine) scanDeadline() time.Time {
+	if e.scanBudget <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(e.scanBudget)
+}
+
+// isDeadlineExceeded checks if the deadline was set and is already in the past
+func isDeadlineExceeded(deadline time.Time) bool {
+	return !deadline.IsZero() && time.Now().After(deadline)
+}
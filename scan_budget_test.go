@@ -0,0 +1,76 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// slowRuleMock represents a rule that takes some time to run, returning a single finding
+type slowRuleMock struct {
+	duration time.Duration
+}
+
+func (r *slowRuleMock) Run(path string) ([]Finding, error) {
+	time.Sleep(r.duration)
+
+	return []Finding{{ID: "HS-1", SourceLocation: Location{Filename: path}}}, nil
+}
+
+func TestEngineRunWithScanBudget(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"main.go": "package main",
+	})
+
+	// the first rule doesn't sleep, so it always runs inside the budget, and the next ones exceed it
+	rules := []Rule{&slowRuleMock{}}
+	for i := 0; i < 9; i++ {
+		rules = append(rules, &slowRuleMock{duration: 200 * time.Millisecond})
+	}
+
+	t.Run("Should return partial findings and the truncated flag when the budget is exceeded", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetScanBudget(100 * time.Millisecond)
+
+		findings, stats, err := engine.RunWithStats(context.Background(), projectPath, rules...)
+		assert.NoError(t, err)
+		assert.True(t, stats.Truncated)
+		assert.NotEmpty(t, findings)
+		assert.Less(t, len(findings), len(rules))
+		assert.Equal(t, 0, stats.FilesScanned)
+	})
+
+	t.Run("Should not run any rule when the budget is tiny", func(t *testing.T) {
+		engine := NewEngine(0, ".go")
+		engine.SetScanBudget(time.Nanosecond)
+
+		findings, stats, err := engine.RunWithStats(context.Background(), projectPath, &slowRuleMock{})
+		assert.NoError(t, err)
+		assert.True(t, stats.Truncated)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("Should return all findings without a budget", func(t *testing.T) {
+		findings, stats, err := NewEngine(0, ".go").RunWithStats(context.Background(), projectPath, rules[:2]...)
+		assert.NoError(t, err)
+		assert.False(t, stats.Truncated)
+		assert.Len(t, findings, 2)
+		assert.Equal(t, 1, stats.FilesScanned)
+	})
+}
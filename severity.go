@@ -0,0 +1,32 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+// SetSeverityOverrides changes the severity of the findings of some rules, which allows each team to promote or demote
+// rules without changing them. The map key is the rule id and the value the new severity
+func (e *Engine) SetSeverityOverrides(severities map[string]string) {
+	e.severities = severities
+}
+
+// overrideSeverities sets the overridden severity of each finding which rule has one
+func (e *Engine) overrideSeverities(findings []Finding) []Finding {
+	for index := range findings {
+		if severity, ok := e.severities[findings[index].ID]; ok {
+			findings[index].Severity = severity
+		}
+	}
+
+	return findings
+}
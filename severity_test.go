@@ -0,0 +1,66 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngineRunWithSeverityOverrides(t *testing.T) {
+	projectPath := createProject(t, map[string]string{
+		"main.go": "package main",
+	})
+
+	rules := []Rule{
+		newRuleMock([]Finding{{ID: "HS-1", Severity: "LOW"}}, nil),
+		newRuleMock([]Finding{{ID: "HS-2", Severity: "MEDIUM"}}, nil),
+	}
+
+	testCases := []struct {
+		name               string
+		severities         map[string]string
+		expectedSeverities []string
+	}{
+		{
+			name:               "Should set the overridden severity of the rule findings",
+			severities:         map[string]string{"HS-1": "CRITICAL", "HS-3": "INFO"},
+			expectedSeverities: []string{"CRITICAL", "MEDIUM"},
+		},
+		{
+			name:               "Should keep the rule severities when there are no overrides",
+			expectedSeverities: []string{"LOW", "MEDIUM"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			engine := NewEngine(0, ".go")
+			engine.SetSeverityOverrides(testCase.severities)
+
+			findings, err := engine.Run(context.Background(), projectPath, rules...)
+			assert.NoError(t, err)
+
+			var severities []string
+			for _, finding := range findings {
+				severities = append(severities, finding.Severity)
+			}
+
+			assert.Equal(t, testCase.expectedSeverities, severities)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+// Summary holds the total of findings of an analysis and how many of them there are for each severity
+type Summary struct {
+	Total int
+	// BySeverity holds the total of findings of each severity, where the key is the finding severity as reported by the
+	// rule, e.g. "HIGH", so findings without a severity are counted with an empty key
+	BySeverity map[string]int
+}
+
+// Summarize counts the findings by severity, which is commonly used to open reports like "3 critical, 10 high"
+func Summarize(findings []Finding) Summary {
+	summary := Summary{
+		Total:      len(findings),
+		BySeverity: make(map[string]int),
+	}
+
+	for index := range findings {
+		summary.BySeverity[findings[index].Severity]++
+	}
+
+	return summary
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		findings []Finding
+		expected Summary
+	}{
+		{
+			name:     "Should return an empty summary when there are no findings",
+			expected: Summary{BySeverity: map[string]int{}},
+		},
+		{
+			name: "Should count the findings by severity",
+			findings: []Finding{
+				{ID: "HS-1", Severity: "CRITICAL"},
+				{ID: "HS-2", Severity: "HIGH"},
+				{ID: "HS-3", Severity: "CRITICAL"},
+				{ID: "HS-4", Severity: "LOW"},
+				{ID: "HS-5"},
+			},
+			expected: Summary{
+				Total:      5,
+				BySeverity: map[string]int{"CRITICAL": 2, "HIGH": 1, "LOW": 1, "": 1},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Summarize(testCase.findings))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"sync"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+// FileRunner represents anything that applies rules over a text file that was already created, like Rule and Rules
+type FileRunner interface {
+	RunFile(file *File) ([]engine.Finding, error)
+}
+
+// FindingsCache keeps the findings of each file by its relative path and content hash, so re-analyzing a file that
+// didn't change returns the cached findings instead of running the rules again. It's safe for concurrent use, but the
+// same file being analyzed at the same time by many goroutines may run the rules more than once
+type FindingsCache struct {
+	runner  FileRunner
+	mutex   sync.Mutex
+	entries map[string]findingsCacheEntry
+}
+
+// findingsCacheEntry holds the findings of a file and the hash of the content they were found in
+type findingsCacheEntry struct {
+	contentHash string
+	findings    []engine.Finding
+}
+
+// NewFindingsCache creates an empty cache of the findings of the runner
+func NewFindingsCache(runner FileRunner) *FindingsCache {
+	return &FindingsCache{
+		runner:  runner,
+		entries: make(map[string]findingsCacheEntry),
+	}
+}
+
+// RunFile returns the cached findings of the file if its content didn't change since the last analysis, otherwise the
+// runner is applied over the file and its findings cached. Errors are never cached
+func (c *FindingsCache) RunFile(file *File) ([]engine.Finding, error) {
+	contentHash := file.ContentHash()
+
+	if findings, ok := c.get(file.RelativePath, contentHash); ok {
+		return findings, nil
+	}
+
+	findings, err := c.runner.RunFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	c.put(file.RelativePath, findingsCacheEntry{contentHash: contentHash, findings: findings})
+
+	return c.copyFindings(findings), nil
+}
+
+// Invalidate removes the cached findings of the files, so they are analyzed again on the next run
+func (c *FindingsCache) Invalidate(relativePaths ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, relativePath := range relativePaths {
+		delete(c.entries, relativePath)
+	}
+}
+
+// get returns a copy of the cached findings of the file if they were found in the same content
+func (c *FindingsCache) get(relativePath, contentHash string) ([]engine.Finding, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[relativePath]
+	if !ok || entry.contentHash != contentHash {
+		return nil, false
+	}
+
+	return c.copyFindings(entry.findings), true
+}
+
+// put caches the findings of the file, replacing the ones of any previous content
+func (c *FindingsCache) put(relativePath string, entry findingsCacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[relativePath] = entry
+}
+
+// copyFindings deep copies the findings, including their captures, fix and slices, so callers changing the returned
+// findings don't change the cached ones
+func (c *FindingsCache) copyFindings(findings []engine.Finding) []engine.Finding {
+	if findings == nil {
+		return nil
+	}
+
+	copied := make([]engine.Finding, 0, len(findings))
+	for index := range findings {
+		copied = append(copied, copyFinding(findings[index]))
+	}
+
+	return copied
+}
+
+// copyFinding copies the fields of the finding that are shared by reference, since the other ones are copied by value
+func copyFinding(finding engine.Finding) engine.Finding {
+	finding.CWEs = copyStrings(finding.CWEs)
+	finding.Tags = copyStrings(finding.Tags)
+	finding.Captures = copyCaptures(finding.Captures)
+
+	if finding.Fix != nil {
+		fix := *finding.Fix
+		finding.Fix = &fix
+	}
+
+	return finding
+}
+
+// copyCaptures copies the captures map keeping nil maps as nil
+func copyCaptures(captures map[string]string) map[string]string {
+	if captures == nil {
+		return nil
+	}
+
+	copied := make(map[string]string, len(captures))
+	for name, value := range captures {
+		copied[name] = value
+	}
+
+	return copied
+}
+
+// copyStrings copies the slice keeping nil slices as nil
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	return append(make([]string, 0, len(values)), values...)
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+// countingRunner counts how many times the rule is applied, returning the error when it's set
+type countingRunner struct {
+	rule  *Rule
+	err   error
+	calls int
+}
+
+func (r *countingRunner) RunFile(file *File) ([]engine.Finding, error) {
+	r.calls++
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return r.rule.RunFile(file)
+}
+
+func TestFindingsCache(t *testing.T) {
+	rule, err := NewRule("HS-JS-1", Regular, []string{`eval\(`})
+	assert.NoError(t, err)
+
+	newFile := func(t *testing.T, content string) *File {
+		file, err := NewTextFile("main.js", []byte(content))
+		assert.NoError(t, err)
+
+		return file
+	}
+
+	t.Run("Should reuse the cached findings when the file content didn't change", func(t *testing.T) {
+		runner := &countingRunner{rule: rule}
+		cache := NewFindingsCache(runner)
+
+		first, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+
+		second, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, runner.calls)
+		assert.Len(t, second, 1)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("Should analyze again the files whose content changed", func(t *testing.T) {
+		runner := &countingRunner{rule: rule}
+		cache := NewFindingsCache(runner)
+
+		_, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+
+		findings, err := cache.RunFile(newFile(t, "const a = 1\neval(input)\n"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, runner.calls)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, 2, findings[0].SourceLocation.Line)
+	})
+
+	t.Run("Should analyze again the invalidated files", func(t *testing.T) {
+		runner := &countingRunner{rule: rule}
+		cache := NewFindingsCache(runner)
+
+		_, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+
+		cache.Invalidate("main.js")
+
+		_, err = cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, runner.calls)
+	})
+
+	t.Run("Should not change the cached findings when the returned ones are changed", func(t *testing.T) {
+		cache := NewFindingsCache(rule)
+
+		findings, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+
+		findings[0].ID = "changed"
+
+		findings, err = cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "HS-JS-1", findings[0].ID)
+	})
+
+	t.Run("Should not change the cached captures, fix and slices when the returned ones are changed", func(t *testing.T) {
+		fixRule, err := NewRule("HS-JS-2", Regular, []string{`(?P<call>eval)\(`}, WithFixTemplate("safeEval("),
+			WithMetadata(engine.Metadata{ID: "HS-JS-2", Tags: []string{"injection"}, CWEs: []string{"CWE-95"}}))
+		assert.NoError(t, err)
+
+		cache := NewFindingsCache(fixRule)
+
+		findings, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+
+		findings[0].Captures["call"] = "changed"
+		findings[0].Fix.Replacement = "changed"
+		findings[0].Tags[0] = "changed"
+		findings[0].CWEs[0] = "changed"
+
+		findings, err = cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "eval", findings[0].Captures["call"])
+		assert.Equal(t, "safeEval(", findings[0].Fix.Replacement)
+		assert.Equal(t, []string{"injection"}, findings[0].Tags)
+		assert.Equal(t, []string{"CWE-95"}, findings[0].CWEs)
+	})
+
+	t.Run("Should not cache the errors", func(t *testing.T) {
+		runner := &countingRunner{rule: rule, err: errors.New("run error")}
+		cache := NewFindingsCache(runner)
+
+		_, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.Error(t, err)
+
+		runner.err = nil
+
+		findings, err := cache.RunFile(newFile(t, "eval(input)\n"))
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, 2, runner.calls)
+	})
+}
+
+func TestFileContentHash(t *testing.T) {
+	file, err := NewTextFile("main.js", []byte("eval(input)\n"))
+	assert.NoError(t, err)
+
+	other, err := NewTextFile("other.js", []byte("eval(input)\n"))
+	assert.NoError(t, err)
+
+	changed, err := NewTextFile("main.js", []byte("eval(other)\n"))
+	assert.NoError(t, err)
+
+	assert.Len(t, file.ContentHash(), 64)
+	assert.Equal(t, file.ContentHash(), other.ContentHash())
+	assert.NotEqual(t, file.ContentHash(), changed.ContentHash())
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"sort"
+)
+
+// CommentMode represents where the regex expressions of a rule can match in relation to the file comments
+type CommentMode int
+
+const (
+	// MatchAnywhere ignores the comments, the regex expressions can match in comments and code. This is the default
+	MatchAnywhere CommentMode = iota
+
+	// MatchOnlyComments only reports matches that start inside a comment
+	MatchOnlyComments
+
+	// MatchExcludeComments only reports matches that start outside comments, like "password" used in code
+	MatchExcludeComments
+)
+
+// CommentSyntax represents how comments are written in the file language
+type CommentSyntax struct {
+	LineMarkers []string // LineMarkers holds the markers of comments that end with the line (e.g. // and #)
+	BlockStart  string   // BlockStart holds the marker of the beginning of block comments (e.g. /*)
+	BlockEnd    string   // BlockEnd holds the marker of the ending of block comments (e.g. */)
+}
+
+// DefaultCommentSyntax is the comment syntax used when none is informed, it supports C style and hash comments
+var DefaultCommentSyntax = CommentSyntax{
+	LineMarkers: []string{"//", "#"},
+	BlockStart:  "/*",
+	BlockEnd:    "*/",
+}
+
+// stringDelimiters holds the runes that start and end string literals, comment markers inside them are ignored
+var stringDelimiters = []byte{'"', '\'', '`'}
+
+// CommentRegions returns the beginning and ending index of each comment of the file using a light lexer, that also
+// handles string literals to not mistake a marker inside a string for a comment (e.g. "http://"). The regions are not
+// stored in the file, so they are computed every time this function is called
+func (f *File) CommentRegions(syntax CommentSyntax) [][]int {
+	lexer := commentLexer{content: f.Content, syntax: syntax}
+
+	return lexer.run()
+}
+
+// isInComment checks if the index is inside any of the comment regions, which must be sorted
+func isInComment(regions [][]int, index int) bool {
+	position := sort.Search(len(regions), func(i int) bool { return regions[i][1] > index })
+
+	return position < len(regions) && regions[position][0] <= index
+}
+
+// commentLexer holds the state of the search for comments in the content
+type commentLexer struct {
+	content  []byte
+	syntax   CommentSyntax
+	position int
+	regions  [][]int
+}
+
+// run walks through the content skipping string literals and collecting the comment regions
+func (l *commentLexer) run() [][]int {
+	for l.position < len(l.content) {
+		switch {
+		case l.hasPrefix(l.syntax.BlockStart):
+			l.addRegion(l.syntax.BlockStart, l.syntax.BlockEnd)
+		case l.hasAnyLineMarkerPrefix():
+			l.addRegion("", "\n")
+		case bytes.IndexByte(stringDelimiters, l.content[l.position]) >= 0:
+			l.skipString()
+		default:
+			l.position++
+		}
+	}
+
+	return l.regions
+}
+
+// hasPrefix checks if the content at the current position starts with the marker
+func (l *commentLexer) hasPrefix(marker string) bool {
+	return marker != "" && bytes.HasPrefix(l.content[l.position:], []byte(marker))
+}
+
+// hasAnyLineMarkerPrefix checks if the content at the current position starts with any line comment marker
+func (l *commentLexer) hasAnyLineMarkerPrefix() bool {
+	for _, marker := range l.syntax.LineMarkers {
+		if l.hasPrefix(marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addRegion adds a comment region from the current position to the end marker, which is included in the region for
+// block comments and excluded for line comments. Comments without end marker go until the end of the content
+func (l *commentLexer) addRegion(start, end string) {
+	begin := l.position
+	searchFrom := begin + len(start)
+
+	endIndex := bytes.Index(l.content[searchFrom:], []byte(end))
+	if endIndex < 0 {
+		l.position = len(l.content)
+	} else if start == "" {
+		l.position = searchFrom + endIndex
+	} else {
+		l.position = searchFrom + endIndex + len(end)
+	}
+
+	l.regions = append(l.regions, []int{begin, l.position})
+}
+
+// skipString moves the position to after the end of the string literal that starts at the current position. Strings
+// delimited by quotes also end with the line, so an unterminated string doesn't hide the rest of the file
+func (l *commentLexer) skipString() {
+	delimiter := l.content[l.position]
+	l.position++
+
+	for l.position < len(l.content) {
+		current := l.content[l.position]
+		l.position++
+
+		if current == '\\' {
+			l.position++
+		} else if isStringEnd(current, delimiter) {
+			return
+		}
+	}
+}
+
+// isStringEnd checks if the character ends the string literal started by the delimiter
+func isStringEnd(current, delimiter byte) bool {
+	return current == delimiter || (current == '\n' && delimiter != '`')
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleComments = `// password of the database
+const url = "http://localhost" // the password is in the env
+/* multi line
+   password */
+const password = process.env.PASSWORD # password
+const text = 'not a // comment'
+`
+
+func TestCommentRegions(t *testing.T) {
+	t.Run("Should return the comment regions ignoring markers inside strings", func(t *testing.T) {
+		file, err := NewTextFile("main.js", []byte(sampleComments))
+		assert.NoError(t, err)
+
+		var comments []string
+		for _, region := range file.CommentRegions(DefaultCommentSyntax) {
+			comments = append(comments, string(file.Content[region[0]:region[1]]))
+		}
+
+		assert.Equal(t, []string{
+			"// password of the database",
+			"// the password is in the env",
+			"/* multi line\n   password */",
+			"# password",
+		}, comments)
+	})
+
+	t.Run("Should use the informed comment syntax", func(t *testing.T) {
+		file, err := NewTextFile("main.sql", []byte("SELECT 1 -- comment\n# not a comment\n"))
+		assert.NoError(t, err)
+
+		regions := file.CommentRegions(CommentSyntax{LineMarkers: []string{"--"}})
+		assert.Equal(t, [][]int{{9, 19}}, regions)
+	})
+}
+
+func TestRunWithCommentMode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		commentMode   CommentMode
+		expectedLines []int
+	}{
+		{
+			name:          "Should report matches in comments and code",
+			commentMode:   MatchAnywhere,
+			expectedLines: []int{1, 2, 4, 5, 5, 5},
+		},
+		{
+			name:          "Should report only matches in comments",
+			commentMode:   MatchOnlyComments,
+			expectedLines: []int{1, 2, 4, 5},
+		},
+		{
+			name:          "Should report only matches outside comments",
+			commentMode:   MatchExcludeComments,
+			expectedLines: []int{5, 5},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("main.js", []byte(sampleComments))
+			assert.NoError(t, err)
+
+			rule := &Rule{
+				Type:        OrMatch,
+				Expressions: []*regexp.Regexp{regexp.MustCompile(`(?i)password`)},
+				CommentMode: testCase.commentMode,
+			}
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+
+			var lines []int
+			for _, finding := range findings {
+				lines = append(lines, finding.SourceLocation.Line)
+			}
+
+			assert.Equal(t, testCase.expectedLines, lines)
+			assert.Nil(t, file.commentRegions)
+		})
+	}
+}
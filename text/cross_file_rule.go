@@ -0,0 +1,164 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"regexp"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+// CrossFileRule represents a vulnerability that can only be identified correlating the content of different files,
+// like a secret defined in one file and used unsafely in another one. It works like the AndMatch type, but the regex
+// expressions can match in any of the files instead of all of them matching in the same file
+type CrossFileRule struct {
+	engine.Metadata
+	Expressions []*regexp.Regexp
+}
+
+// Run start the analysis with a single file, which is the same as running AndMatch in this file
+func (r *CrossFileRule) Run(path string) ([]engine.Finding, error) {
+	return r.RunUnit([]string{path})
+}
+
+// RunUnit reads all files once and for each regex expression search for the first file that matches it. If any of
+// the regex expressions don't match in any file, it should return nil. In case of all have matched, the first finding
+// of the first regex expression will be returned to be used to generate the report. Since every expression is searched
+// in every file, the content of all files is held in memory during the analysis
+func (r *CrossFileRule) RunUnit(paths []string) ([]engine.Finding, error) {
+	files, err := readFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	if finding := r.findFirstIfAllMatch(files); finding != nil {
+		return []engine.Finding{*finding}, nil
+	}
+
+	return nil, nil
+}
+
+// findFirstIfAllMatch returns the first finding of the first regex expression if all of them match any of the files,
+// otherwise nil
+func (r *CrossFileRule) findFirstIfAllMatch(files []*File) *engine.Finding {
+	var firstFinding *engine.Finding
+
+	for _, expression := range r.Expressions {
+		finding := findFirstMatch(&r.Metadata, expression, files)
+		if finding == nil {
+			return nil
+		}
+
+		if firstFinding == nil {
+			firstFinding = finding
+		}
+	}
+
+	return firstFinding
+}
+
+// readFiles creates a text file for each path, ignoring binary files
+func readFiles(paths []string) ([]*File, error) {
+	files := make([]*File, 0, len(paths))
+
+	for _, path := range paths {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if file != nil {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// readFile creates a text file for the path, returning nil if it's a binary file
+func readFile(path string) (*File, error) {
+	reader := &Rule{}
+
+	content, err := reader.getFileContent(path)
+	if err != nil || reader.isBinary(content) {
+		return nil, err
+	}
+
+	return NewTextFile(path, content)
+}
+
+// findFirstMatch returns the finding of the first match of the regex expression in the files, or nil if it doesn't
+// match any of them
+func findFirstMatch(metadata *engine.Metadata, expression *regexp.Regexp, files []*File) *engine.Finding {
+	rule := &Rule{
+		Metadata:    *metadata,
+		Type:        OrMatch,
+		Expressions: []*regexp.Regexp{expression},
+	}
+
+	for _, file := range files {
+		if findings, _ := rule.runOrMatch(file); len(findings) > 0 {
+			return &findings[0]
+		}
+	}
+
+	return nil
+}
+
+// UnitExistenceRule represents a policy that the content can't exist anywhere in the analysis, like "no file may
+// contain X". Different from the OrMatch type, that reports every match of every file, it reports a single finding
+// with the first match of the first file, in the informed order, that matches any of the regex expressions
+type UnitExistenceRule struct {
+	engine.Metadata
+	Expressions []*regexp.Regexp
+}
+
+// Run start the analysis with a single file, reporting the first match of the file
+func (r *UnitExistenceRule) Run(path string) ([]engine.Finding, error) {
+	return r.RunUnit([]string{path})
+}
+
+// RunUnit reads the files one at a time and returns the first match of the first file that matches any regex
+// expression, or nil if none of them match. The files after the first match are not read
+func (r *UnitExistenceRule) RunUnit(paths []string) ([]engine.Finding, error) {
+	for _, path := range paths {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if finding := r.findFirstMatch(file); finding != nil {
+			return []engine.Finding{*finding}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findFirstMatch returns the first match of the file of the first regex expression that matches it, or nil if none of
+// them match or the file is nil, since it's binary
+func (r *UnitExistenceRule) findFirstMatch(file *File) *engine.Finding {
+	if file == nil {
+		return nil
+	}
+
+	for _, expression := range r.Expressions {
+		if finding := findFirstMatch(&r.Metadata, expression, []*File{file}); finding != nil {
+			return finding
+		}
+	}
+
+	return nil
+}
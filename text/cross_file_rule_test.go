@@ -0,0 +1,188 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+func TestCrossFileRuleRunUnit(t *testing.T) {
+	projectPath := t.TempDir()
+
+	files := map[string]string{
+		"config.js": "module.exports = { secret: 'my-secret' }\n",
+		"server.js": "const config = require('./config')\nconsole.log(config.secret)\n",
+		"main.js":   "console.log('hi')\n",
+		"empty.js":  "",
+	}
+
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(projectPath, name), []byte(content), 0o600))
+	}
+
+	testCases := []struct {
+		name             string
+		paths            []string
+		expressions      []*regexp.Regexp
+		expectedFilename string
+	}{
+		{
+			name:             "Should return 1 finding when each expression match in a different file",
+			paths:            []string{"config.js", "server.js", "main.js", "empty.js"},
+			expressions:      []*regexp.Regexp{regexp.MustCompile(`secret:`), regexp.MustCompile(`console\.log\(config\.`)},
+			expectedFilename: "config.js",
+		},
+		{
+			name:        "Should return 0 findings when only one of the files is analyzed",
+			paths:       []string{"config.js", "main.js"},
+			expressions: []*regexp.Regexp{regexp.MustCompile(`secret:`), regexp.MustCompile(`console\.log\(config\.`)},
+		},
+		{
+			name:        "Should return 0 findings when an expression does not match any file",
+			paths:       []string{"config.js", "server.js", "main.js"},
+			expressions: []*regexp.Regexp{regexp.MustCompile(`secret:`), regexp.MustCompile(`should-not-match`)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var paths []string
+			for _, path := range testCase.paths {
+				paths = append(paths, filepath.Join(projectPath, path))
+			}
+
+			rule := &CrossFileRule{Expressions: testCase.expressions}
+
+			findings, err := rule.RunUnit(paths)
+			assert.NoError(t, err)
+
+			if testCase.expectedFilename == "" {
+				assert.Empty(t, findings)
+
+				return
+			}
+
+			assert.Len(t, findings, 1)
+			assert.Equal(t, filepath.Join(projectPath, testCase.expectedFilename), findings[0].SourceLocation.Filename)
+		})
+	}
+
+	t.Run("Should run as unit rule when used by the engine", func(t *testing.T) {
+		rules := []engine.Rule{
+			&CrossFileRule{
+				Expressions: []*regexp.Regexp{regexp.MustCompile(`secret:`), regexp.MustCompile(`config\.secret`)},
+			},
+			&Rule{Type: OrMatch, Expressions: []*regexp.Regexp{regexp.MustCompile(`console\.log`)}},
+		}
+
+		findings, stats, err := engine.NewEngine(0, ".js").RunWithStats(context.Background(), projectPath, rules...)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 3)
+		assert.Equal(t, 4, stats.FilesScanned)
+		assert.Equal(t, 5, stats.RulesEvaluated)
+	})
+
+	t.Run("Should return error when file does not exist", func(t *testing.T) {
+		rule := &CrossFileRule{Expressions: []*regexp.Regexp{regexp.MustCompile(`secret:`)}}
+
+		findings, err := rule.RunUnit([]string{filepath.Join(projectPath, "invalid.js")})
+		assert.Error(t, err)
+		assert.Nil(t, findings)
+	})
+}
+
+func TestUnitExistenceRuleRunUnit(t *testing.T) {
+	projectPath := t.TempDir()
+
+	files := map[string]string{
+		"a.js": "console.log('a')\n",
+		"b.js": "console.log('b')\neval(code)\nnew Function(code)\n",
+		"c.js": "eval(other)\n",
+	}
+
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(projectPath, name), []byte(content), 0o600))
+	}
+
+	paths := []string{
+		filepath.Join(projectPath, "a.js"), filepath.Join(projectPath, "b.js"), filepath.Join(projectPath, "c.js"),
+	}
+
+	testCases := []struct {
+		name             string
+		expressions      []*regexp.Regexp
+		expectedFilename string
+		expectedLine     int
+	}{
+		{
+			name:             "Should return a single finding with the first offending file",
+			expressions:      []*regexp.Regexp{regexp.MustCompile(`new Function\(`), regexp.MustCompile(`eval\(`)},
+			expectedFilename: "b.js",
+			expectedLine:     3,
+		},
+		{
+			name:        "Should return 0 findings when no file matches",
+			expressions: []*regexp.Regexp{regexp.MustCompile(`should-not-match`)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule := &UnitExistenceRule{
+				Metadata:    engine.Metadata{ID: "HORUSEC-1"},
+				Expressions: testCase.expressions,
+			}
+
+			findings, err := rule.RunUnit(paths)
+			assert.NoError(t, err)
+
+			if testCase.expectedFilename == "" {
+				assert.Empty(t, findings)
+
+				return
+			}
+
+			assert.Len(t, findings, 1)
+			assert.Equal(t, "HORUSEC-1", findings[0].ID)
+			assert.Equal(t, filepath.Join(projectPath, testCase.expectedFilename), findings[0].SourceLocation.Filename)
+			assert.Equal(t, testCase.expectedLine, findings[0].SourceLocation.Line)
+		})
+	}
+
+	t.Run("Should not read the files after the first match", func(t *testing.T) {
+		rule := &UnitExistenceRule{Expressions: []*regexp.Regexp{regexp.MustCompile(`eval\(`)}}
+
+		findings, err := rule.RunUnit(append(paths[:2:2], filepath.Join(projectPath, "missing.js")))
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+	})
+
+	t.Run("Should return a single finding when used by the engine", func(t *testing.T) {
+		rule := &UnitExistenceRule{Expressions: []*regexp.Regexp{regexp.MustCompile(`eval\(`)}}
+
+		findings, err := engine.NewEngine(0, ".js").Run(context.Background(), projectPath, rule)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, filepath.Join(projectPath, "b.js"), findings[0].SourceLocation.Filename)
+	})
+}
@@ -15,16 +15,35 @@
 package text
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"unicode/utf8"
+)
+
+// ColumnUnit represents how the columns reported by the engine are counted
+type ColumnUnit int
+
+const (
+	// RuneColumn counts the columns in runes, so a multibyte UTF-8 character counts as a single column, which is what
+	// most editors display. This is the default
+	RuneColumn ColumnUnit = iota
+
+	// ByteColumn counts the columns in bytes, so a multibyte UTF-8 character counts as many columns as its bytes
+	ByteColumn
 )
 
 // regexNewLine regex representing the new line hexadecimal, equivalent of \n.
 var regexNewLine = regexp.MustCompile("\x0a")
 
-// File represents a file to be analyzed
+// File represents a file to be analyzed. The file is never modified by the rules, so the same file can be analyzed by
+// many rules at the same time, as long as its fields are not changed during the analysis
 type File struct {
 	// AbsolutePath holds the complete path to the file (e.g. /home/user/myProject/router/handler.js)
 	AbsolutePath         string
@@ -33,10 +52,28 @@ type File struct {
 	Name                 string  // Name holds only the single name of the file (e.g. handler.js)
 	newlineIndexes       [][]int // newlineIndexes holds information about where is the beginning and ending of each line
 	newlineEndingIndexes []int   // newlineEndingIndexes represents the *start* index of each '\n' rune in the file
+
+	// ColumnUnit holds how the columns returned by FindLineAndColumn are counted, RuneColumn by default. Rules count
+	// the columns of their findings in their own column unit
+	ColumnUnit ColumnUnit
+
+	commentRegions [][]int // commentRegions holds the comment regions used by the rule being ran, if it needs them
+	full           *File   // full holds the whole file when the file is a prefix of it, used to extract the samples
 }
 
 // NewTextFile create a new text file with all necessary info filled
 func NewTextFile(relativeFilePath string, content []byte) (*File, error) {
+	file := newFile(relativeFilePath, content)
+
+	if err := file.setAbsFilePath(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// newFile creates a new text file with all info filled except the absolute path, which depends on where the file is
+func newFile(relativeFilePath string, content []byte) *File {
 	file := &File{
 		RelativePath:   relativeFilePath,
 		Content:        content,
@@ -44,13 +81,34 @@ func NewTextFile(relativeFilePath string, content []byte) (*File, error) {
 		newlineIndexes: regexNewLine.FindAllIndex(content, -1),
 	}
 
-	if err := file.setAbsFilePath(); err != nil {
+	file.setNewlineEndingIndexes()
+
+	return file
+}
+
+// NewGzipTextFile create a new text file from a gzip compressed content. The content is decompressed before creating
+// the file, so all indexes, lines and columns are relative to the decompressed content
+func NewGzipTextFile(relativeFilePath string, compressedContent []byte) (*File, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(compressedContent))
+	if err != nil {
 		return nil, err
 	}
 
-	file.setNewlineEndingIndexes()
+	defer reader.Close()
 
-	return file, nil
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTextFile(relativeFilePath, content)
+}
+
+// ContentHash returns the hex encoded SHA-256 of the file content
+func (f *File) ContentHash() string {
+	hash := sha256.Sum256(f.Content)
+
+	return hex.EncodeToString(hash[:])
 }
 
 // setAbsFilePath verifies if the filepath is absolute and set, otherwise it will parse and then set
@@ -74,6 +132,114 @@ func (f *File) setNewlineEndingIndexes() {
 	}
 }
 
+// withColumnUnit returns a copy of the file that counts the columns in the column unit, the original file is not
+// changed
+func (f *File) withColumnUnit(columnUnit ColumnUnit) *File {
+	file := *f
+	file.ColumnUnit = columnUnit
+
+	return &file
+}
+
+// lineBounds returns the beginning and ending index of each line of the file content, where the ending index is the
+// index of the '\n' rune or the end of the content for the last line of a file without a trailing newline
+func (f *File) lineBounds() [][]int {
+	bounds := make([][]int, 0, len(f.newlineEndingIndexes)+1)
+	start := 0
+
+	for _, end := range f.newlineEndingIndexes {
+		bounds = append(bounds, []int{start, end})
+		start = end + 1
+	}
+
+	if start < len(f.Content) {
+		bounds = append(bounds, []int{start, len(f.Content)})
+	}
+
+	return bounds
+}
+
+// Lines returns the content of each line of the file, where the index 0 is the line 1 reported by FindLineAndColumn.
+// Line endings, both "\n" and "\r\n", are not included, and a file ending with a newline doesn't have an empty last
+// line
+func (f *File) Lines() []string {
+	bounds := f.lineBounds()
+	lines := make([]string, 0, len(bounds))
+
+	for index := range bounds {
+		lines = append(lines, f.lineContent(bounds[index]))
+	}
+
+	return lines
+}
+
+// Line returns the content of the line n, using the same 1-based numbering of FindLineAndColumn. It returns false if
+// the file doesn't have the line
+func (f *File) Line(n int) (string, bool) {
+	if n <= 0 || n > len(f.newlineEndingIndexes)+1 {
+		return "", false
+	}
+
+	start, end := f.lineBoundsOf(n)
+	if n > len(f.newlineEndingIndexes) && start == end {
+		// a file ending with a newline doesn't have an empty last line
+		return "", false
+	}
+
+	return f.lineContent([]int{start, end}), true
+}
+
+// lineBoundsOf returns where the line n begins and ends, without its line ending. The line must exist in the file
+func (f *File) lineBoundsOf(n int) (start, end int) {
+	start, end = 0, len(f.Content)
+
+	if n > 1 {
+		start = f.newlineEndingIndexes[n-2] + 1
+	}
+
+	if n <= len(f.newlineEndingIndexes) {
+		end = f.newlineEndingIndexes[n-1]
+	}
+
+	return start, end
+}
+
+// lineContent returns the content between the line bounds without the carriage return of "\r\n" line endings
+func (f *File) lineContent(bounds []int) string {
+	return strings.TrimSuffix(string(f.Content[bounds[0]:bounds[1]]), "\r")
+}
+
+// whole returns the whole file when the file is a prefix of it, otherwise the file itself
+func (f *File) whole() *File {
+	if f.full != nil {
+		return f.full
+	}
+
+	return f
+}
+
+// prefix returns a copy of the file holding only the first size bytes of the content. Since the offsets are the same
+// of the original file, lines and columns found in the prefix are valid for the whole file, and the code samples are
+// extracted from the whole file, so a line cut by the prefix size is still complete in the sample. The rules that check
+// the content around the matches, like NotMatchLine and NotFollowedBy, use the whole file too
+func (f *File) prefix(size int) *File {
+	if size >= len(f.Content) {
+		return f
+	}
+
+	prefix := *f
+	prefix.full = f.whole()
+
+	// the newlines of the prefix are the ones before the size, the capacity is limited so they are never appended
+	lines := sort.Search(len(f.newlineIndexes), func(index int) bool { return f.newlineIndexes[index][0] >= size })
+
+	prefix.Content = f.Content[:size]
+	prefix.newlineIndexes = f.newlineIndexes[:lines:lines]
+	prefix.newlineEndingIndexes = f.newlineEndingIndexes[:lines:lines]
+
+	return &prefix
+}
+
 // nolint:funlen,wsl // todo complex function need to be improved
 // FindLineAndColumn get line and column using the beginning index of the example code
 func (f *File) FindLineAndColumn(findingIndex int) (line, column int) {
@@ -89,30 +255,33 @@ func (f *File) FindLineAndColumn(findingIndex int) (line, column int) {
 	lineIndex := f.binarySearch(findingIndex, f.newlineEndingIndexes)
 
 	// Now with the right index found we have to get the previous \n
-	// from the findingIndex, so it gets the right line
-	if lineIndex < len(f.newlineEndingIndexes) {
-		// we add +1 here because we want the line to
-		// reflect the "human" line count, not the indexed one in the slice
-		line = lineIndex + 1
-		endOfCurrentLine := lineIndex - 1
+	// from the findingIndex, so it gets the right line. If the index is
+	// after the last \n, the finding is in the last line of a file without
+	// a trailing newline, which is still a valid line.
 
-		// If there is no previous line the finding is in the beginning
-		// of the file, so we just normalize to avoid signing issues (like -1 messing the indexing)
-		if endOfCurrentLine <= 0 {
-			endOfCurrentLine = 0
-		}
+	// we add +1 here because we want the line to
+	// reflect the "human" line count, not the indexed one in the slice
+	line = lineIndex + 1
 
-		// now we access the textual index in the slice to ge the column
-		endOfCurrentLineInTheFile := f.newlineEndingIndexes[endOfCurrentLine]
+	// If there is no previous line the finding is in the beginning
+	// of the file, so the column is the index itself
+	if lineIndex == 0 {
+		column = findingIndex
+	} else {
+		// now we access the textual index of the previous \n in the slice to get the column
+		column = (findingIndex - 1) - f.newlineEndingIndexes[lineIndex-1]
+	}
 
-		if lineIndex == 0 {
-			column = findingIndex
-		} else {
-			column = (findingIndex - 1) - endOfCurrentLineInTheFile
-		}
+	return line, f.columnInUnit(findingIndex, column)
+}
+
+// columnInUnit converts the column counted in bytes to the file column unit
+func (f *File) columnInUnit(findingIndex, byteColumn int) int {
+	if f.ColumnUnit == ByteColumn || byteColumn <= 0 {
+		return byteColumn
 	}
 
-	return line, column
+	return utf8.RuneCount(f.Content[findingIndex-byteColumn : findingIndex])
 }
 
 // binarySearch function uses this search algorithm to find the index of the matching element.
@@ -128,20 +297,29 @@ func (f *File) binarySearch(searchIndex int, collection []int) (foundIndex int)
 // nolint:funlen // todo complex function, needs to be improved
 // ExtractSample search for the vulnerable code using the finding indexes
 func (f *File) ExtractSample(findingIndex int) string {
-	lineIndex := f.binarySearch(findingIndex, f.newlineEndingIndexes)
+	if f.full != nil {
+		return f.full.ExtractSample(findingIndex)
+	}
 
-	if lineIndex < len(f.newlineEndingIndexes) {
-		endOfPreviousLine := 0
+	start, end := f.lineRangeOf(findingIndex)
 
-		if lineIndex > 0 {
-			endOfPreviousLine = f.newlineEndingIndexes[lineIndex-1] + 1
-		}
+	return strings.TrimSpace(string(f.Content[start:end]))
+}
+
+// lineRangeOf returns the beginning index of the line that contains the index and its ending index, which is the index
+// of the "\n" or the content length for the last line
+func (f *File) lineRangeOf(index int) (start, end int) {
+	lineIndex := f.binarySearch(index, f.newlineEndingIndexes)
 
-		endOfCurrentLine := f.newlineEndingIndexes[lineIndex]
-		lineContent := f.Content[endOfPreviousLine:endOfCurrentLine]
+	if lineIndex > 0 {
+		start = f.newlineEndingIndexes[lineIndex-1] + 1
+	}
 
-		return strings.TrimSpace(string(lineContent))
+	// The last line of a file without a trailing newline ends with the file content
+	end = len(f.Content)
+	if lineIndex < len(f.newlineEndingIndexes) {
+		end = f.newlineEndingIndexes[lineIndex]
 	}
 
-	return ""
+	return start, end
 }
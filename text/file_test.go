@@ -15,9 +15,12 @@
 package text
 
 import (
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -205,3 +208,209 @@ func TestNewTextFile(t *testing.T) {
 		assert.Lenf(t, file.newlineEndingIndexes, 30, "sample go contains 30 ending indexes")
 	})
 }
+
+func TestFindLineAndColumnWithColumnUnit(t *testing.T) {
+	content := "const greeting = \"olá 👋\"; eval(greeting)\nconst café = \"☕\"; eval(café)\n"
+
+	testCases := []struct {
+		name            string
+		columnUnit      ColumnUnit
+		regexExpression string
+		expectedLine    int
+		expectedColumn  int
+	}{
+		{
+			name:            "Should count columns in runes in the first line",
+			columnUnit:      RuneColumn,
+			regexExpression: `eval\(greeting`,
+			expectedLine:    1,
+			expectedColumn:  26,
+		},
+		{
+			name:            "Should count columns in bytes in the first line",
+			columnUnit:      ByteColumn,
+			regexExpression: `eval\(greeting`,
+			expectedLine:    1,
+			expectedColumn:  30,
+		},
+		{
+			name:            "Should count columns in runes in the second line",
+			columnUnit:      RuneColumn,
+			regexExpression: `eval\(café`,
+			expectedLine:    2,
+			expectedColumn:  18,
+		},
+		{
+			name:            "Should count columns in bytes in the second line",
+			columnUnit:      ByteColumn,
+			regexExpression: `eval\(café`,
+			expectedLine:    2,
+			expectedColumn:  21,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			findingIndex, err := getFindingIndex(content, testCase.regexExpression)
+			assert.NoError(t, err)
+
+			file, err := NewTextFile("test", []byte(content))
+			assert.NoError(t, err)
+
+			file.ColumnUnit = testCase.columnUnit
+
+			line, column := file.FindLineAndColumn(findingIndex)
+			assert.Equal(t, testCase.expectedLine, line)
+			assert.Equal(t, testCase.expectedColumn, column)
+		})
+	}
+
+	t.Run("Should count columns in the column unit of the rule when running a created file", func(t *testing.T) {
+		file, err := NewTextFile("test", []byte(content))
+		assert.NoError(t, err)
+
+		rule, err := NewRule("HS-1", Regular, []string{`eval\(greeting`}, WithColumnUnit(ByteColumn))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, 30, findings[0].SourceLocation.Column)
+		assert.Equal(t, RuneColumn, file.ColumnUnit)
+	})
+}
+
+func TestFindLineAndColumnWithoutTrailingNewline(t *testing.T) {
+	content := "package main\n\nfunc main() { exec(cmd) }"
+
+	t.Run("Should find line, column and sample in the last line of a file without trailing newline", func(t *testing.T) {
+		findingIndex, err := getFindingIndex(content, `exec\(`)
+		assert.NoError(t, err)
+
+		file, err := NewTextFile("test", []byte(content))
+		assert.NoError(t, err)
+
+		line, column := file.FindLineAndColumn(findingIndex)
+		assert.Equal(t, 3, line)
+		assert.Equal(t, 14, column)
+		assert.Equal(t, "func main() { exec(cmd) }", file.ExtractSample(findingIndex))
+	})
+
+	t.Run("Should find line and column in a file without newlines", func(t *testing.T) {
+		file, err := NewTextFile("test", []byte("exec(cmd)"))
+		assert.NoError(t, err)
+
+		line, column := file.FindLineAndColumn(0)
+		assert.Equal(t, 1, line)
+		assert.Equal(t, 0, column)
+		assert.Equal(t, "exec(cmd)", file.ExtractSample(0))
+	})
+}
+
+func TestNewGzipTextFile(t *testing.T) {
+	compress := func(content string) []byte {
+		buffer := new(bytes.Buffer)
+		writer := gzip.NewWriter(buffer)
+
+		_, err := writer.Write([]byte(content))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+
+		return buffer.Bytes()
+	}
+
+	t.Run("Should return the same findings of the plain file", func(t *testing.T) {
+		plainFile, err := NewTextFile("server.js", []byte(sampleJs))
+		assert.NoError(t, err)
+
+		compressedFile, err := NewGzipTextFile("server.js", compress(sampleJs))
+		assert.NoError(t, err)
+
+		assert.Equal(t, sampleJs, string(compressedFile.Content))
+
+		rule := &Rule{
+			Type:        OrMatch,
+			Expressions: []*regexp.Regexp{regexp.MustCompile(`res\.\w+`), regexp.MustCompile(`server\.listen`)},
+		}
+
+		plainFindings, err := rule.RunFile(plainFile)
+		assert.NoError(t, err)
+
+		compressedFindings, err := rule.RunFile(compressedFile)
+		assert.NoError(t, err)
+
+		assert.Len(t, compressedFindings, 4)
+		assert.Equal(t, plainFindings, compressedFindings)
+	})
+
+	t.Run("Should return error when content is not gzip compressed", func(t *testing.T) {
+		file, err := NewGzipTextFile("server.js", []byte(sampleJs))
+		assert.Error(t, err)
+		assert.Nil(t, file)
+	})
+}
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedLines []string
+	}{
+		{
+			name:          "Should return lines of a file with trailing newline",
+			content:       "package main\n\nfunc main() {}\n",
+			expectedLines: []string{"package main", "", "func main() {}"},
+		},
+		{
+			name:          "Should return lines of a file without trailing newline",
+			content:       "package main\n\nfunc main() {}",
+			expectedLines: []string{"package main", "", "func main() {}"},
+		},
+		{
+			name:          "Should return lines of a file with CRLF line endings",
+			content:       "package main\r\n\r\nfunc main() {}\r\n",
+			expectedLines: []string{"package main", "", "func main() {}"},
+		},
+		{
+			name:          "Should return no lines of an empty file",
+			content:       "",
+			expectedLines: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("main.go", []byte(testCase.content))
+			assert.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedLines, file.Lines())
+
+			for index, expectedLine := range testCase.expectedLines {
+				line, ok := file.Line(index + 1)
+				assert.True(t, ok)
+				assert.Equal(t, expectedLine, line)
+			}
+
+			_, ok := file.Line(0)
+			assert.False(t, ok)
+
+			_, ok = file.Line(len(testCase.expectedLines) + 1)
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("Should return the same line reported by FindLineAndColumn", func(t *testing.T) {
+		file, err := NewTextFile("server.js", []byte(sampleJs))
+		assert.NoError(t, err)
+
+		for _, expression := range []string{`http\.createServer`, `res\.end`, `console\.log`, `server\.listen`} {
+			index := regexp.MustCompile(`(?m)` + expression).FindIndex(file.Content)
+			line, column := file.FindLineAndColumn(index[0])
+
+			lineContent, ok := file.Line(line)
+			assert.True(t, ok)
+			assert.Regexp(t, expression, lineContent[column:])
+			assert.Equal(t, file.ExtractSample(index[0]), strings.TrimSpace(lineContent))
+		}
+	})
+}
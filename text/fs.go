@@ -0,0 +1,83 @@
+// Copyright 2022 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// FilesFromFS walks fsys starting at root and creates a text file for each regular file found. The filter function
+// receives the path of the file inside fsys and should return true for the files that should be analyzed, a nil
+// filter accepts all files. The path inside fsys is used as the file relative path, so it will be the one reported
+// in the findings. The paths inside fsys are not paths of the current directory, so the files absolute paths are empty
+func FilesFromFS(fsys fs.FS, root string, filter func(path string) bool) ([]*File, error) {
+	var files []*File
+
+	err := fs.WalkDir(fsys, root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() || (filter != nil && !filter(path)) {
+			return err
+		}
+
+		return addFileFromFS(fsys, path, &files)
+	})
+
+	return files, err
+}
+
+// addFileFromFS creates the text file of the path inside fsys and adds it to the files
+func addFileFromFS(fsys fs.FS, path string, files *[]*File) error {
+	file, err := newTextFileFromFS(fsys, path)
+	if err != nil {
+		return err
+	}
+
+	*files = append(*files, file)
+
+	return nil
+}
+
+// newTextFileFromFS reads the file content from fsys and creates a new text file with it, without an absolute path
+func newTextFileFromFS(fsys fs.FS, path string) (*File, error) {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFile(path, content), nil
+}
+
+// FilterFiles returns only the files which relative path is inside the directory prefix, which allows analyzing a
+// single directory of files that were already created. The prefix is compared by path elements, so "api" matches
+// "api/server.go" but not "apiv2/server.go", and both the prefix and the relative paths are cleaned before, so "api"
+// also matches "./api/server.go". An empty prefix returns all files
+func FilterFiles(files []*File, prefix string) []*File {
+	prefix = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(prefix)), "/")
+	if prefix == "." || prefix == "" {
+		return files
+	}
+
+	var filtered []*File
+
+	for _, file := range files {
+		path := filepath.ToSlash(filepath.Clean(file.RelativePath))
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			filtered = append(filtered, file)
+		}
+	}
+
+	return filtered
+}
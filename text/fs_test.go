@@ -0,0 +1,177 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"path"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"project/main.go":            {Data: []byte(sampleGo)},
+		"project/api/server.js":      {Data: []byte(sampleJs)},
+		"project/api/handler.go":     {Data: []byte(sampleGo)},
+		"project/docs/README.md":     {Data: []byte("# readme")},
+		"other/ignored.go":           {Data: []byte(sampleGo)},
+		"project/vendor/lib/vend.go": {Data: []byte(sampleGo)},
+	}
+
+	isGoFile := func(filePath string) bool {
+		return path.Ext(filePath) == ".go"
+	}
+
+	testCases := []struct {
+		name          string
+		root          string
+		filter        func(path string) bool
+		expectedPaths []string
+		expectedError bool
+	}{
+		{
+			name:          "Should return only go files inside root",
+			root:          "project",
+			filter:        isGoFile,
+			expectedPaths: []string{"project/api/handler.go", "project/main.go", "project/vendor/lib/vend.go"},
+		},
+		{
+			name: "Should return all files inside root when filter is nil",
+			root: "project",
+			expectedPaths: []string{
+				"project/api/handler.go", "project/api/server.js", "project/docs/README.md",
+				"project/main.go", "project/vendor/lib/vend.go",
+			},
+		},
+		{
+			name:          "Should return error when root does not exist",
+			root:          "invalid",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files, err := FilesFromFS(fsys, testCase.root, testCase.filter)
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var paths []string
+			for _, file := range files {
+				paths = append(paths, file.RelativePath)
+			}
+
+			assert.Equal(t, testCase.expectedPaths, paths)
+		})
+	}
+
+	t.Run("Should run rule over files created from fs", func(t *testing.T) {
+		files, err := FilesFromFS(fsys, "project/api", isGoFile)
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+
+		rule := &Rule{
+			Type:        OrMatch,
+			Expressions: []*regexp.Regexp{regexp.MustCompile(`cmd\.Short`)},
+		}
+
+		findings, err := rule.RunFile(files[0])
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, "project/api/handler.go", findings[0].SourceLocation.Filename)
+		assert.Equal(t, "handler.go", files[0].Name)
+		assert.Empty(t, files[0].AbsolutePath)
+	})
+}
+
+func TestFilterFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"project/main.go":       {Data: []byte(sampleGo)},
+		"project/api/server.go": {Data: []byte(sampleGo)},
+		"project/api/v1/old.go": {Data: []byte(sampleGo)},
+		"project/apiv2/new.go":  {Data: []byte(sampleGo)},
+	}
+
+	files, err := FilesFromFS(fsys, ".", nil)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		prefix        string
+		expectedPaths []string
+	}{
+		{
+			name:          "Should return only files inside the directory",
+			prefix:        "project/api",
+			expectedPaths: []string{"project/api/server.go", "project/api/v1/old.go"},
+		},
+		{
+			name:          "Should accept prefix with trailing separator",
+			prefix:        "project/api/v1/",
+			expectedPaths: []string{"project/api/v1/old.go"},
+		},
+		{
+			name:          "Should return a single file when prefix is the file path",
+			prefix:        "project/main.go",
+			expectedPaths: []string{"project/main.go"},
+		},
+		{
+			name: "Should return all files when prefix is empty",
+			expectedPaths: []string{
+				"project/api/server.go", "project/api/v1/old.go", "project/apiv2/new.go", "project/main.go",
+			},
+		},
+		{
+			name:   "Should return no files when prefix does not match",
+			prefix: "other",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var paths []string
+			for _, file := range FilterFiles(files, testCase.prefix) {
+				paths = append(paths, file.RelativePath)
+			}
+
+			assert.Equal(t, testCase.expectedPaths, paths)
+			assert.Len(t, files, 4)
+		})
+	}
+
+	t.Run("Should clean the relative paths of the files before comparing them", func(t *testing.T) {
+		var uncleanFiles []*File
+
+		for _, relativePath := range []string{"./api/x.go", "api/../api/y.go", "./apiv2/z.go"} {
+			file, err := NewTextFile(relativePath, []byte(sampleGo))
+			assert.NoError(t, err)
+
+			uncleanFiles = append(uncleanFiles, file)
+		}
+
+		filtered := FilterFiles(uncleanFiles, "api")
+		assert.Len(t, filtered, 2)
+		assert.Equal(t, "./api/x.go", filtered[0].RelativePath)
+		assert.Equal(t, "api/../api/y.go", filtered[1].RelativePath)
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"net"
+	"strings"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+const (
+	// AddressHostCapture is the capture name of the host of a URL in the findings of a hardcoded address rule
+	AddressHostCapture = "host"
+
+	// AddressIPCapture is the capture name of a bare IPv4 or IPv6 address in the findings of a hardcoded address rule
+	AddressIPCapture = "ip"
+
+	// octetPattern matches a decimal number from 0 to 255, the longer alternatives first
+	octetPattern = `(?:25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])`
+
+	// ipv4Pattern matches a dotted IPv4 address not surrounded by other word characters, like in "v1.2.3.4"
+	ipv4Pattern = `\b(?:` + octetPattern + `\.){3}` + octetPattern + `\b`
+
+	// ipv6Pattern matches candidates of IPv6 addresses starting and ending with a hex group, like "2001:db8::1". The
+	// candidates are validated with net.ParseIP, since the pattern also matches times and MAC addresses
+	ipv6Pattern = `\b[0-9A-Fa-f]{1,4}(?::[0-9A-Fa-f]{0,4}){2,7}\b`
+
+	// urlPattern matches a URL of the common network schemes until the first space or string delimiter, capturing
+	// its host, which is a name, an IPv4 address or an IPv6 address in brackets
+	urlPattern = `\b(?:https?|ftps?|wss?)://(?P<` + AddressHostCapture + `>\[[0-9A-Fa-f:.]+\]|[^\s/:?#'"\x60<>\\]+)` +
+		`[^\s'"\x60<>\\]*`
+)
+
+// DefaultAddressAllowlist are the networks ignored by NewHardcodedAddressRule, which are the unspecified, loopback,
+// private and link-local ranges of IPv4 and IPv6
+var DefaultAddressAllowlist = parseNetworks(
+	"0.0.0.0/32", "127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "169.254.0.0/16",
+	"::/128", "::1/128", "fc00::/7", "fe80::/10",
+)
+
+// HardcodedAddressRule reports IP addresses and URLs hardcoded in the source code, like in globals or call arguments.
+// It works like a Rule, but the findings which address is in the Allowlist, or a URL to localhost, are ignored
+type HardcodedAddressRule struct {
+	*Rule
+	Allowlist []*net.IPNet
+}
+
+// NewHardcodedAddressRule creates a HardcodedAddressRule using DefaultAddressAllowlist. Each finding holds the host of
+// a URL or the bare IP address in Finding.Captures, using the AddressHostCapture and AddressIPCapture keys
+func NewHardcodedAddressRule(id string, opts ...Option) (*HardcodedAddressRule, error) {
+	pattern := urlPattern + `|(?P<` + AddressIPCapture + `>` + ipv4Pattern + `|` + ipv6Pattern + `)`
+
+	rule, err := NewRule(id, OrMatch, []string{pattern}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HardcodedAddressRule{Rule: rule, Allowlist: DefaultAddressAllowlist}, nil
+}
+
+// Run reads the file in the path the same way as Rule.Run, ignoring the findings of allowed addresses
+func (r *HardcodedAddressRule) Run(path string) ([]engine.Finding, error) {
+	findings, err := r.Rule.Run(path)
+
+	return r.removeAllowedFindings(findings), err
+}
+
+// RunFile runs the rule over a text file the same way as Rule.RunFile, ignoring the findings of allowed addresses
+func (r *HardcodedAddressRule) RunFile(file *File) ([]engine.Finding, error) {
+	findings, err := r.Rule.RunFile(file)
+
+	return r.removeAllowedFindings(findings), err
+}
+
+func (r *HardcodedAddressRule) removeAllowedFindings(findings []engine.Finding) []engine.Finding {
+	var reported []engine.Finding
+
+	for _, finding := range findings {
+		if !r.isAllowed(finding) {
+			reported = append(reported, finding)
+		}
+	}
+
+	return reported
+}
+
+// isAllowed checks if the address of the finding is allowed. Host names are reported, except localhost, while bare IP
+// candidates that aren't valid addresses, like times, are ignored
+func (r *HardcodedAddressRule) isAllowed(finding engine.Finding) bool {
+	if host, ok := finding.Captures[AddressHostCapture]; ok {
+		host = strings.Trim(host, "[]")
+
+		return strings.EqualFold(host, "localhost") || r.isAllowedIP(net.ParseIP(host))
+	}
+
+	ip := net.ParseIP(finding.Captures[AddressIPCapture])
+
+	return ip == nil || r.isAllowedIP(ip)
+}
+
+func (r *HardcodedAddressRule) isAllowedIP(ip net.IP) bool {
+	for _, network := range r.Allowlist {
+		if ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHardcodedAddressRule(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedLines []int
+	}{
+		{
+			name:          "Should match a public IPv4 address used as a call argument",
+			content:       "conn, err := net.Dial(\"udp\", \"8.8.8.8:53\")\n",
+			expectedLines: []int{1},
+		},
+		{
+			name:    "Should not match loopback and private IPv4 addresses",
+			content: "const local = \"127.0.0.1\"\nconst lan = \"192.168.0.10\"\nconst vpc = \"10.1.2.3\"\n",
+		},
+		{
+			name:          "Should match a public IPv6 address in a global",
+			content:       "const dns = \"::1\"\nconst public = \"2001:4860:4860::8888\"\nconst local = \"fe80::1\"\n",
+			expectedLines: []int{2},
+		},
+		{
+			name:          "Should match a URL to a public host",
+			content:       "const api = \"https://api.example.com/v1\"\nfetch('http://172.32.0.1/status')\n",
+			expectedLines: []int{1, 2},
+		},
+		{
+			name:    "Should not match URLs to local hosts",
+			content: "get(\"http://localhost:8080/health\")\nget(\"http://127.0.0.1/\")\nget('http://[::1]:80/')\n",
+		},
+		{
+			name:    "Should not match times, MAC addresses and versions",
+			content: "start := \"12:30:45\"\nmac := \"aa:bb:cc:dd:ee:ff\"\nversion := \"v1.2.3.4\"\n",
+		},
+	}
+
+	rule, err := NewHardcodedAddressRule("HORUSEC-ADDRESS-1")
+	assert.NoError(t, err)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("main.go", []byte(testCase.content))
+			assert.NoError(t, err)
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+
+			var lines []int
+			for _, finding := range findings {
+				lines = append(lines, finding.SourceLocation.Line)
+			}
+
+			assert.Equal(t, testCase.expectedLines, lines)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+const (
+	// privateKeyPattern matches a PEM private key block, from the BEGIN header until the END footer of any key type,
+	// like RSA, EC or OPENSSH, even when the block spans many lines
+	privateKeyPattern = `-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----[\s\S]*?-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----`
+
+	// jwtPattern matches a JSON Web Token, which header and payload are base64url JSON objects, so both start with
+	// "eyJ", the encoding of `{"`
+	jwtPattern = `\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`
+)
+
+// NewKeyMaterialRule creates a rule that reports private keys and JSON Web Tokens pasted into the source code in any
+// position, like globals, call arguments or default values. Since the findings hold the secret in the code sample, it
+// can be combined with WithRedaction
+func NewKeyMaterialRule(id string, opts ...Option) (*Rule, error) {
+	return NewRule(id, OrMatch, []string{privateKeyPattern, jwtPattern}, opts...)
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"regexp/syntax"
+)
+
+// Warning represents a construct of a regular expression pattern that makes the matching expensive
+type Warning struct {
+	// PatternIndex holds the index of the pattern in the patterns informed to NewRule, it's always 0 in LintPattern
+	PatternIndex int
+	Message      string
+}
+
+// String returns the pattern index and the warning message
+func (w Warning) String() string {
+	return fmt.Sprintf("pattern at index %d: %s", w.PatternIndex, w.Message)
+}
+
+// LintPattern checks the regular expression pattern for expensive constructs, like nested unbounded quantifiers as in
+// "(a+)+" and many unbounded wildcards as in ".*a.*b". The regexp package runs in linear time, so they can't lead to
+// catastrophic backtracking, but they still slow the matching of big files and usually are mistakes. Invalid patterns
+// have no warnings, since they are already reported when compiled
+func LintPattern(pattern string) []Warning {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []Warning
+
+	lintNestedQuantifiers(re, &warnings)
+	lintUnboundedWildcards(re, &warnings)
+
+	return warnings
+}
+
+// lintUnboundedWildcards adds a warning when the pattern has more than one unbounded wildcard
+func lintUnboundedWildcards(re *syntax.Regexp, warnings *[]Warning) {
+	if wildcards := countUnboundedWildcards(re); wildcards > 1 {
+		*warnings = append(*warnings, Warning{
+			Message: fmt.Sprintf("%d unbounded wildcards like .* in the same pattern", wildcards),
+		})
+	}
+}
+
+// lintPatterns returns the warnings of all patterns, with the index of the pattern that has each warning
+func lintPatterns(patterns []string) []Warning {
+	var warnings []Warning
+
+	for index, pattern := range patterns {
+		for _, warning := range LintPattern(pattern) {
+			warning.PatternIndex = index
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings
+}
+
+// lintNestedQuantifiers adds a warning for each unbounded quantifier that holds another unbounded quantifier
+func lintNestedQuantifiers(re *syntax.Regexp, warnings *[]Warning) {
+	if isUnboundedRepeat(re) && hasUnboundedRepeat(re.Sub) {
+		*warnings = append(*warnings, Warning{Message: "nested unbounded quantifiers like (a+)+"})
+
+		return
+	}
+
+	for _, sub := range re.Sub {
+		lintNestedQuantifiers(sub, warnings)
+	}
+}
+
+// hasUnboundedRepeat checks if any of the expressions, or their sub expressions, is an unbounded quantifier
+func hasUnboundedRepeat(res []*syntax.Regexp) bool {
+	for _, re := range res {
+		if isUnboundedRepeat(re) || hasUnboundedRepeat(re.Sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countUnboundedWildcards counts the unbounded quantifiers of any character, like .* and .+
+func countUnboundedWildcards(re *syntax.Regexp) int {
+	count := 0
+
+	if isUnboundedRepeat(re) && (re.Sub[0].Op == syntax.OpAnyChar || re.Sub[0].Op == syntax.OpAnyCharNotNL) {
+		count++
+	}
+
+	for _, sub := range re.Sub {
+		count += countUnboundedWildcards(sub)
+	}
+
+	return count
+}
+
+// isUnboundedRepeat checks if the expression is a quantifier without a max, like *, + and {n,}
+func isUnboundedRepeat(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus:
+		return true
+	case syntax.OpRepeat:
+		return re.Max == -1
+	default:
+		return false
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintPattern(t *testing.T) {
+	testCases := []struct {
+		name             string
+		pattern          string
+		expectedMessages []string
+	}{
+		{
+			name:             "Should warn about nested unbounded quantifiers",
+			pattern:          `^(a+)+$`,
+			expectedMessages: []string{"nested unbounded quantifiers like (a+)+"},
+		},
+		{
+			name:             "Should warn about many unbounded wildcards",
+			pattern:          `password.*=.*secret`,
+			expectedMessages: []string{"2 unbounded wildcards like .* in the same pattern"},
+		},
+		{
+			name:    "Should not warn about a safe pattern",
+			pattern: `(?i)(password|secret)\s*=\s*["'][^"']{8,}["']`,
+		},
+		{
+			name:    "Should not warn about bounded nested quantifiers",
+			pattern: `(\d{1,3}\.){3}\d{1,3}`,
+		},
+		{
+			name:    "Should not warn about an invalid pattern",
+			pattern: `(a+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var messages []string
+			for _, warning := range LintPattern(testCase.pattern) {
+				messages = append(messages, warning.Message)
+			}
+
+			assert.Equal(t, testCase.expectedMessages, messages)
+		})
+	}
+}
+
+func TestNewRuleWarnings(t *testing.T) {
+	t.Run("Should report the warnings with the index of the pattern", func(t *testing.T) {
+		rule, err := NewRule("HS-1", OrMatch, []string{`eval\(`, `(\w+\s?)*$`})
+		assert.NoError(t, err)
+		assert.Len(t, rule.Warnings, 1)
+		assert.Equal(t, 1, rule.Warnings[0].PatternIndex)
+		assert.Equal(t, "pattern at index 1: nested unbounded quantifiers like (a+)+", rule.Warnings[0].String())
+	})
+
+	t.Run("Should not report warnings for safe patterns", func(t *testing.T) {
+		rule, err := NewRule("HS-1", OrMatch, []string{`eval\(`, `exec\(`})
+		assert.NoError(t, err)
+		assert.Empty(t, rule.Warnings)
+	})
+}
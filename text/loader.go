@@ -0,0 +1,156 @@
+// Copyright 2022 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"os"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+// DefaultLoadConcurrency is the amount of files read at the same time by LoadFiles when none is informed
+const DefaultLoadConcurrency = 10
+
+// LoadOptions holds the configurations of LoadFiles and StreamFiles
+type LoadOptions struct {
+	// Concurrency holds how many files are read at the same time. In StreamFiles it also bounds how many files are held
+	// in memory waiting to be handled. Zero or lower uses DefaultLoadConcurrency
+	Concurrency int
+}
+
+// loadResult holds the text file, or the error, of a path read by StreamFiles
+type loadResult struct {
+	file *File
+	err  error
+}
+
+// LoadFiles reads the files concurrently and creates a text file for each one, in the same order of the paths
+// regardless of which read ends first. The informed paths are used as the files relative paths, the same way as
+// Rule.Run. Unreadable files don't stop the loading, the files that could be loaded are returned together with an
+// engine.FileErrors error holding the error of each unreadable file. All files are held in memory by the returned
+// slice, StreamFiles should be used when the files can be handled one at a time
+func LoadFiles(paths []string, opts LoadOptions) ([]*File, error) {
+	files := make([]*File, 0, len(paths))
+
+	err := StreamFiles(paths, opts, func(file *File) error {
+		files = append(files, file)
+
+		return nil
+	})
+
+	return files, err
+}
+
+// StreamFiles reads the files concurrently the same way as LoadFiles, but instead of returning all of them, each text
+// file is passed to handle in the same order of the paths. The reads are at most Concurrency files ahead of the file
+// being handled, so that's the max amount of files held in memory by it. If handle returns an error the streaming is
+// stopped and the error returned, otherwise the errors of the unreadable files are returned as engine.FileErrors
+func StreamFiles(paths []string, opts LoadOptions, handle func(file *File) error) error {
+	stream := newFileStream(paths, opts.concurrency())
+	defer close(stream.done)
+
+	go stream.readAhead()
+
+	return stream.handleInOrder(handle)
+}
+
+// concurrency returns the informed concurrency or DefaultLoadConcurrency when it's zero or lower
+func (o LoadOptions) concurrency() int {
+	if o.Concurrency <= 0 {
+		return DefaultLoadConcurrency
+	}
+
+	return o.Concurrency
+}
+
+// fileStream holds the state of a StreamFiles call. Each path has its own result channel, so the files are handled in
+// order, and the window holds a room for each file read but not handled yet
+type fileStream struct {
+	paths   []string
+	results []chan loadResult
+	window  chan struct{}
+	done    chan struct{}
+}
+
+// newFileStream creates the stream of the paths with a window of the concurrency size
+func newFileStream(paths []string, concurrency int) *fileStream {
+	results := make([]chan loadResult, len(paths))
+	for index := range results {
+		results[index] = make(chan loadResult, 1)
+	}
+
+	return &fileStream{
+		paths:   paths,
+		results: results,
+		window:  make(chan struct{}, concurrency),
+		done:    make(chan struct{}),
+	}
+}
+
+// readAhead starts reading each path as soon as there is room in the window, sending the result to the channel of the
+// path. It stops when done is closed
+func (s *fileStream) readAhead() {
+	for index, path := range s.paths {
+		select {
+		case s.window <- struct{}{}:
+		case <-s.done:
+			return
+		}
+
+		go func(result chan loadResult, path string) {
+			file, err := loadFile(path)
+			result <- loadResult{file: file, err: err}
+		}(s.results[index], path)
+	}
+}
+
+// handleInOrder waits the result of each path in order, passing the file to handle and releasing its room in the
+// window, so the next path can be read
+func (s *fileStream) handleInOrder(handle func(file *File) error) error {
+	var fileErrors engine.FileErrors
+
+	for index, path := range s.paths {
+		result := <-s.results[index]
+
+		if result.err != nil {
+			fileErrors = append(fileErrors, &engine.FileError{Path: path, Err: result.err})
+		} else if err := handle(result.file); err != nil {
+			return err
+		}
+
+		<-s.window
+	}
+
+	return loadError(fileErrors)
+}
+
+// loadError returns the errors of the unreadable files, or nil if all files were read
+func loadError(fileErrors engine.FileErrors) error {
+	if len(fileErrors) == 0 {
+		return nil
+	}
+
+	return fileErrors
+}
+
+// loadFile reads the file content and creates a new text file with it
+func loadFile(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTextFile(path, content)
+}
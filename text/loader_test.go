@@ -0,0 +1,116 @@
+// Copyright 2022 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+func TestLoadFiles(t *testing.T) {
+	projectPath := t.TempDir()
+
+	var paths []string
+	for index := 0; index < 25; index++ {
+		path := filepath.Join(projectPath, fmt.Sprintf("file-%d.js", index))
+		assert.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf("const index = %d\n", index)), 0o600))
+
+		paths = append(paths, path)
+	}
+
+	t.Run("Should load the files in the order of the paths for any concurrency", func(t *testing.T) {
+		for _, concurrency := range []int{0, 1, 3, 100} {
+			files, err := LoadFiles(paths, LoadOptions{Concurrency: concurrency})
+			assert.NoError(t, err)
+			assert.Len(t, files, len(paths))
+
+			for index, file := range files {
+				assert.Equal(t, paths[index], file.RelativePath)
+				assert.Equal(t, fmt.Sprintf("const index = %d\n", index), string(file.Content))
+			}
+		}
+	})
+
+	t.Run("Should return the loaded files and the errors of the unreadable ones", func(t *testing.T) {
+		missingPaths := []string{filepath.Join(projectPath, "missing-1.js"), filepath.Join(projectPath, "missing-2.js")}
+
+		files, err := LoadFiles([]string{missingPaths[0], paths[0], missingPaths[1], paths[1]}, LoadOptions{})
+		assert.Error(t, err)
+		assert.Len(t, files, 2)
+		assert.Equal(t, paths[0], files[0].RelativePath)
+		assert.Equal(t, paths[1], files[1].RelativePath)
+
+		var fileErrors engine.FileErrors
+		assert.True(t, errors.As(err, &fileErrors))
+		assert.Len(t, fileErrors, 2)
+
+		for index, fileError := range fileErrors {
+			assert.Equal(t, missingPaths[index], fileError.Path)
+			assert.True(t, errors.Is(fileError, os.ErrNotExist))
+		}
+	})
+
+	t.Run("Should stream the files in order reading at most the concurrency ahead", func(t *testing.T) {
+		streamPaths := make([]string, 0, len(paths))
+		for index := range paths {
+			path := filepath.Join(t.TempDir(), fmt.Sprintf("stream-%d.js", index))
+			assert.NoError(t, os.WriteFile(path, []byte("const streamed = true\n"), 0o600))
+
+			streamPaths = append(streamPaths, path)
+		}
+
+		var streamed []string
+
+		err := StreamFiles(streamPaths, LoadOptions{Concurrency: 3}, func(file *File) error {
+			if len(streamed) == 0 {
+				// With a window of 3 files the last path can't have been read yet, so removing it must fail its read.
+				assert.NoError(t, os.Remove(streamPaths[len(streamPaths)-1]))
+			}
+
+			streamed = append(streamed, file.RelativePath)
+
+			return nil
+		})
+
+		var fileErrors engine.FileErrors
+		assert.True(t, errors.As(err, &fileErrors))
+		assert.Len(t, fileErrors, 1)
+		assert.Equal(t, streamPaths[len(streamPaths)-1], fileErrors[0].Path)
+		assert.Equal(t, streamPaths[:len(streamPaths)-1], streamed)
+	})
+
+	t.Run("Should stop streaming when the handler returns error", func(t *testing.T) {
+		handled := 0
+		errHandle := errors.New("handle error")
+
+		err := StreamFiles(paths, LoadOptions{Concurrency: 2}, func(file *File) error {
+			handled++
+			if handled == 3 {
+				return errHandle
+			}
+
+			return nil
+		})
+		assert.Equal(t, errHandle, err)
+		assert.Equal(t, 3, handled)
+	})
+}
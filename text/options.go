@@ -0,0 +1,143 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"regexp"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+// Option represents an optional configuration of a rule created by NewRule
+type Option func(rule *Rule) error
+
+// WithMetadata sets the rule metadata, the id informed to NewRule is kept
+func WithMetadata(metadata engine.Metadata) Option {
+	return func(rule *Rule) error {
+		metadata.ID = rule.ID
+		rule.Metadata = metadata
+
+		return nil
+	}
+}
+
+// WithForbiddenPatterns compiles and sets the forbidden regular expressions used by ConditionalMatch
+func WithForbiddenPatterns(patterns ...string) Option {
+	return func(rule *Rule) error {
+		expressions, err := compilePatterns(patterns)
+		if err != nil {
+			return err
+		}
+
+		rule.ForbiddenExpressions = expressions
+
+		return nil
+	}
+}
+
+// WithSuppressionMarker sets the keyword used in comments to suppress the rule findings
+func WithSuppressionMarker(marker string) Option {
+	return func(rule *Rule) error {
+		rule.SuppressionMarker = marker
+
+		return nil
+	}
+}
+
+// WithColumnUnit sets how the columns of the rule findings are counted
+func WithColumnUnit(columnUnit ColumnUnit) Option {
+	return func(rule *Rule) error {
+		rule.ColumnUnit = columnUnit
+
+		return nil
+	}
+}
+
+// WithScanPrefixBytes sets how many bytes from the beginning of the file should be analyzed
+func WithScanPrefixBytes(size int) Option {
+	return func(rule *Rule) error {
+		rule.ScanPrefixBytes = size
+
+		return nil
+	}
+}
+
+// WithNotFollowedBy compiles and sets the regular expression that can't match right after the rule matches. The pattern
+// is anchored to the end of each match, so "X" with NotFollowedBy "Y" works like the negative lookahead "X(?!Y)"
+func WithNotFollowedBy(pattern string) Option {
+	return func(rule *Rule) error {
+		expression, err := regexp.Compile(`^(?:` + pattern + `)`)
+		if err != nil {
+			return fmt.Errorf("invalid not followed by pattern: %w", err)
+		}
+
+		rule.NotFollowedBy = expression
+		rule.anchoredNotFollowedBy = expression
+
+		return nil
+	}
+}
+
+// WithCommentMode sets where the regex expressions can match in relation to the file comments
+func WithCommentMode(commentMode CommentMode) Option {
+	return func(rule *Rule) error {
+		rule.CommentMode = commentMode
+
+		return nil
+	}
+}
+
+// WithCommentSyntax sets how comments are written in the analyzed files
+func WithCommentSyntax(syntax CommentSyntax) Option {
+	return func(rule *Rule) error {
+		rule.CommentSyntax = &syntax
+
+		return nil
+	}
+}
+
+// WithFixTemplate sets the replacement suggested to the matched content, it can reference the capture groups like $1
+func WithFixTemplate(template string) Option {
+	return func(rule *Rule) error {
+		rule.FixTemplate = template
+
+		return nil
+	}
+}
+
+// WithRedaction marks the rule as sensitive, masking the matched content in the code samples of the findings except for
+// its first keepChars characters
+func WithRedaction(keepChars int) Option {
+	return func(rule *Rule) error {
+		if keepChars < 0 {
+			return fmt.Errorf("redaction keep chars can't be negative: %d", keepChars)
+		}
+
+		rule.Sensitive = true
+		rule.RedactKeepChars = keepChars
+
+		return nil
+	}
+}
+
+// WithPositionBase sets from which number the lines and columns of the findings are counted
+func WithPositionBase(base engine.PositionBase) Option {
+	return func(rule *Rule) error {
+		rule.PositionBase = base
+
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// redactionMask is the content that replaces the redacted part of the matched content, it has a fixed length so the
+// length of the secret is not exposed
+const redactionMask = "****"
+
+// extractRedactedSample returns the code sample of the line where the match begins, replacing the matched content of
+// the line, except for its first keepChars characters, by the redaction mask
+func (f *File) extractRedactedSample(findingIndex []int, keepChars int) string {
+	if f.full != nil {
+		return f.full.extractRedactedSample(findingIndex, keepChars)
+	}
+
+	start, end := f.lineRangeOf(findingIndex[0])
+
+	matchEnd := findingIndex[1]
+	if matchEnd > end {
+		matchEnd = end
+	}
+
+	keptEnd := findingIndex[0] + keptLength(f.Content[findingIndex[0]:matchEnd], keepChars)
+
+	return strings.TrimSpace(string(f.Content[start:keptEnd]) + redactionMask + string(f.Content[matchEnd:end]))
+}
+
+// redactValue replaces the value, except for its first keepChars characters, by the redaction mask. Empty values are
+// kept empty, since there is nothing to expose
+func redactValue(value string, keepChars int) string {
+	if value == "" {
+		return value
+	}
+
+	return value[:keptLength([]byte(value), keepChars)] + redactionMask
+}
+
+// keptLength returns the length in bytes of the first keepChars characters of the content. A content that isn't
+// longer than the kept characters would be fully exposed, so none of it is kept instead
+func keptLength(content []byte, keepChars int) int {
+	length := 0
+	for kept := 0; kept < keepChars && length < len(content); kept++ {
+		_, size := utf8.DecodeRune(content[length:])
+		length += size
+	}
+
+	if length == len(content) {
+		return 0
+	}
+
+	return length
+}
+
+// containsMatchedContent checks if the value contains the content of the match or of any of its capture groups
+func containsMatchedContent(value string, findingIndex []int, content []byte) bool {
+	for group := 0; group < len(findingIndex)/2; group++ {
+		start, end := findingIndex[2*group], findingIndex[2*group+1]
+		if start >= 0 && end > start && strings.Contains(value, string(content[start:end])) {
+			return true
+		}
+	}
+
+	return false
+}
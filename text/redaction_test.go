@@ -0,0 +1,100 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWithRedaction(t *testing.T) {
+	content := "const config = {\n  token: \"ghp_abcdefghijklmnop\", // github\n}\n"
+
+	file, err := NewTextFile("config.js", []byte(content))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		opts           []Option
+		expectedSample string
+	}{
+		{
+			name:           "Should mask the matched secret keeping the leading chars for a sensitive rule",
+			opts:           []Option{WithRedaction(4)},
+			expectedSample: `token: "ghp_****", // github`,
+		},
+		{
+			name:           "Should mask the whole matched secret when no chars are kept",
+			opts:           []Option{WithRedaction(0)},
+			expectedSample: `token: "****", // github`,
+		},
+		{
+			name:           "Should mask the whole matched secret when it's not longer than the kept chars",
+			opts:           []Option{WithRedaction(100)},
+			expectedSample: `token: "****", // github`,
+		},
+		{
+			name:           "Should keep the sample intact for a normal rule",
+			expectedSample: `token: "ghp_abcdefghijklmnop", // github`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := NewRule("HS-LEAKS-1", Regular, []string{`ghp_[a-z]+`}, testCase.opts...)
+			assert.NoError(t, err)
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+			assert.Len(t, findings, 1)
+
+			assert.Equal(t, testCase.expectedSample, findings[0].CodeSample)
+			assert.Equal(t, 2, findings[0].SourceLocation.Line)
+			assert.Equal(t, 10, findings[0].SourceLocation.Column)
+			assert.Equal(t, "ghp_", content[findings[0].SourceLocation.Offset:findings[0].SourceLocation.Offset+4])
+		})
+	}
+
+	t.Run("Should mask the captures and skip fixes exposing the secret for a sensitive rule", func(t *testing.T) {
+		rule, err := NewRule("HS-LEAKS-1", Regular, []string{`(ghp_[a-z]+)`},
+			WithRedaction(2), WithFixTemplate(`os.Getenv("$1")`))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, `token: "gh****", // github`, findings[0].CodeSample)
+		assert.Equal(t, map[string]string{"1": "gh****"}, findings[0].Captures)
+		assert.Nil(t, findings[0].Fix)
+	})
+
+	t.Run("Should keep fixes that don't expose the secret for a sensitive rule", func(t *testing.T) {
+		rule, err := NewRule("HS-LEAKS-1", Regular, []string{`(ghp_[a-z]+)`},
+			WithRedaction(2), WithFixTemplate(`process.env.GITHUB_TOKEN`))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.NotNil(t, findings[0].Fix)
+		assert.Equal(t, "process.env.GITHUB_TOKEN", findings[0].Fix.Replacement)
+	})
+
+	t.Run("Should return error for negative kept chars", func(t *testing.T) {
+		_, err := NewRule("HS-LEAKS-1", Regular, []string{`ghp_[a-z]+`}, WithRedaction(-1))
+		assert.Error(t, err)
+	})
+}
@@ -20,6 +20,9 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 
 	engine "github.com/ZupIT/horusec-engine"
 )
@@ -40,6 +43,14 @@ const (
 	// AndMatch need that all regex expressions match to report the vulnerability, it will get the first regex expression
 	// the use as base to the reported vulnerability
 	AndMatch
+
+	// ConditionalMatch works like AndMatch using the regex expressions, but it will only report the vulnerability if
+	// none of the forbidden regex expressions match the file
+	ConditionalMatch
+
+	// NotMatchLine works like NotMatch, but checking each line of the file independently. It will report any line that
+	// don't match all regex expressions, blank lines are ignored
+	NotMatchLine
 )
 
 // peMagicBytes hexadecimal used to find windows binaries
@@ -51,11 +62,109 @@ var (
 
 // Rule represents the vulnerability that should be searched in the file. It contains some predefined information about
 // the vulnerability like the id, name, description, severity, confidence, match type that should be applied and the
-// regular expressions used to match the vulnerable code
+// regular expressions used to match the vulnerable code. Running a rule doesn't change it, so the same rule can be ran
+// from many goroutines at the same time
 type Rule struct {
 	engine.Metadata
 	Type        MatchType
 	Expressions []*regexp.Regexp
+	// ForbiddenExpressions holds the regular expressions that can't match the file, used only by ConditionalMatch
+	ForbiddenExpressions []*regexp.Regexp
+	// SuppressionMarker holds the keyword used in comments to suppress findings of the rule, e.g.
+	// DefaultSuppressionMarker. If empty the findings can't be suppressed
+	SuppressionMarker string
+	// ColumnUnit holds how the columns of the findings are counted, RuneColumn by default
+	ColumnUnit ColumnUnit
+	// ScanPrefixBytes holds how many bytes from the beginning of the file should be analyzed, like the license header.
+	// Zero or lower means that the whole file will be analyzed
+	ScanPrefixBytes int
+	// NotFollowedBy holds a regular expression that can't match right after the regex expressions matches, which
+	// simulates a negative lookahead like "X(?!Y)" that is not supported by the regexp package. Matches followed by it
+	// are ignored. It's always anchored to the end of the match, so it doesn't need to start with "^"
+	NotFollowedBy *regexp.Regexp
+	// CommentMode holds where the regex expressions can match in relation to the comments, MatchAnywhere by default.
+	// It's ignored by NotMatch and NotMatchLine
+	CommentMode CommentMode
+	// CommentSyntax holds how comments are written in the analyzed files, DefaultCommentSyntax is used if nil
+	CommentSyntax *CommentSyntax
+	// FixTemplate holds the replacement suggested to the content matched by the regex expressions, it can reference
+	// the capture groups like $1 or ${name}, the same way as regexp.Expand. If empty the findings don't have a fix
+	FixTemplate string
+	// Sensitive marks that the rule matches secrets, so the matched content is masked in the code sample and in the
+	// captures of the findings keeping only its first RedactKeepChars characters, and fixes which replacement would
+	// contain the matched content are not suggested. The locations are kept intact
+	Sensitive       bool
+	RedactKeepChars int
+	// PositionBase holds from which number the lines and columns of the findings are counted, engine.DefaultBase by
+	// default. The base is stored in the finding location, so it can be converted back by the engine features
+	PositionBase engine.PositionBase
+	// Warnings holds the expensive constructs found by LintPattern in the patterns informed to NewRule, they don't stop
+	// the rule from being created, but should be reviewed before the rule is deployed
+	Warnings []Warning
+
+	// anchoredNotFollowedBy holds NotFollowedBy anchored to the beginning of the content, it's set by WithNotFollowedBy
+	// or lazily on the first run for rules created without it
+	anchoredNotFollowedBy *regexp.Regexp
+	anchorOnce            sync.Once
+}
+
+// NewRule creates a new rule compiling all regular expressions patterns up front, so invalid patterns are reported
+// before the analysis starts with the index of the pattern that failed. The options are applied in the informed order,
+// any option error is returned as well. Expensive constructs found in the patterns are reported in Rule.Warnings
+func NewRule(id string, matchType MatchType, patterns []string, opts ...Option) (*Rule, error) {
+	if err := validateRule(matchType, patterns); err != nil {
+		return nil, err
+	}
+
+	expressions, err := compilePatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &Rule{Metadata: engine.Metadata{ID: id}, Type: matchType, Expressions: expressions}
+	rule.Warnings = lintPatterns(patterns)
+
+	return rule.withOptions(opts)
+}
+
+// validateRule checks if the match type exists and if there is at least one pattern
+func validateRule(matchType MatchType, patterns []string) error {
+	if matchType < OrMatch || matchType > NotMatchLine {
+		return fmt.Errorf("invalid rule type %d", matchType)
+	}
+
+	if len(patterns) == 0 {
+		return fmt.Errorf("at least one pattern is required")
+	}
+
+	return nil
+}
+
+// compilePatterns compiles each regular expression pattern, returning the index of the pattern that failed
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	expressions := make([]*regexp.Regexp, 0, len(patterns))
+
+	for index, pattern := range patterns {
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at index %d: %w", index, err)
+		}
+
+		expressions = append(expressions, expression)
+	}
+
+	return expressions, nil
+}
+
+// withOptions applies the options to the rule in the informed order, returning the first option error
+func (r *Rule) withOptions(opts []Option) (*Rule, error) {
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
 }
 
 // Run start a static code analysis using regular expressions, it will read the file content as bytes and create a text
@@ -67,16 +176,48 @@ func (r *Rule) Run(path string) ([]engine.Finding, error) {
 		return nil, err
 	}
 
-	if r.isBinary(content) {
+	textFile, err := NewTextFile(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.RunFile(textFile)
+}
+
+// RunFile start a static code analysis using regular expressions over a text file that was already created, like the
+// ones returned by FilesFromFS. Binary files are ignored the same way as in Run. The columns are counted in the column
+// unit of the rule, regardless of the column unit of the file
+func (r *Rule) RunFile(file *File) ([]engine.Finding, error) {
+	if r.isBinary(file.Content) {
 		return nil, nil
 	}
 
-	textFile, err := NewTextFile(path, content)
+	file = r.prepareFile(file)
+
+	findings, err := r.runByRuleType(file)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.runByRuleType(textFile)
+	return r.applyPositionBase(r.removeSuppressedFindings(file, findings)), nil
+}
+
+// prepareFile returns a copy of the file configured the way the rule analyzes it, with the rule column unit, prefix
+// and comment regions. The informed file is never changed
+func (r *Rule) prepareFile(file *File) *File {
+	if file.ColumnUnit != r.ColumnUnit {
+		file = file.withColumnUnit(r.ColumnUnit)
+	}
+
+	if r.ScanPrefixBytes > 0 {
+		file = file.prefix(r.ScanPrefixBytes)
+	}
+
+	if r.CommentMode != MatchAnywhere {
+		file = r.withCommentRegions(file)
+	}
+
+	return file
 }
 
 // getFileContent opens the file using the file path, reads and returns its contents as bytes. After all done closes
@@ -107,6 +248,10 @@ func (r *Rule) runByRuleType(file *File) ([]engine.Finding, error) {
 		return r.runNotMatch(file)
 	case AndMatch:
 		return r.runAndMatch(file)
+	case ConditionalMatch:
+		return r.runConditionalMatch(file)
+	case NotMatchLine:
+		return r.runNotMatchLine(file)
 	}
 
 	return nil, fmt.Errorf("invalid rule type")
@@ -129,6 +274,48 @@ func (r *Rule) runNotMatch(file *File) ([]engine.Finding, error) {
 	return findings, nil
 }
 
+// runNotMatchLine for each non-blank line of the file will check if all regex expressions match the line content,
+// and create a finding for the lines that didn't. Since the whole line is the vulnerable code, the column will always
+// be the beginning of the line
+func (r *Rule) runNotMatchLine(file *File) ([]engine.Finding, error) {
+	var findings []engine.Finding
+
+	for index, bounds := range file.lineBounds() {
+		if finding := r.newNotMatchLineFinding(file, index+1, bounds[0]); finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// newNotMatchLineFinding creates the finding of the line that begins at the offset if it's not blank and doesn't match
+// all regex expressions, otherwise nil. The last line of a prefix can be cut, so the line is taken from the whole file
+func (r *Rule) newNotMatchLineFinding(file *File, line, offset int) *engine.Finding {
+	lineContent, _ := file.whole().Line(line)
+
+	codeSample := strings.TrimSpace(lineContent)
+	if codeSample == "" || r.isAllExpressionsMatch(lineContent) {
+		return nil
+	}
+
+	finding := r.newFinding(file.RelativePath, codeSample, line, 0)
+	finding.SourceLocation.Offset = offset
+
+	return &finding
+}
+
+// isAllExpressionsMatch checks if all regex expressions match the content
+func (r *Rule) isAllExpressionsMatch(content string) bool {
+	for _, expression := range r.Expressions {
+		if !expression.MatchString(content) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // runAndMatch for each regex expression will search for matches in the file and return they index and create the
 // findings with them. If any of the regex expressions don't match, it should return nil, all regex expressions should
 // match to be a valid vulnerability. In case of all have matched the first finding will be returned to be used to
@@ -140,9 +327,11 @@ func (r *Rule) runAndMatch(file *File) ([]engine.Finding, error) {
 	isFailedToMatchAll := false
 
 	for _, expression := range r.Expressions {
-		findingIndexes := expression.FindAllIndex(file.Content, -1)
-		if findingIndexes != nil {
-			findings = append(findings, r.createFindingsFromIndexes(findingIndexes, file)...)
+		findingIndexes := expression.FindAllSubmatchIndex(file.Content, -1)
+
+		// matches followed by forbidden content don't create findings, so they don't count as a match
+		if expressionFindings := r.createFindingsFromIndexes(expression, findingIndexes, file); expressionFindings != nil {
+			findings = append(findings, expressionFindings...)
 
 			continue
 		}
@@ -155,6 +344,18 @@ func (r *Rule) runAndMatch(file *File) ([]engine.Finding, error) {
 	return r.getFirstFindingIfAllMatched(isFailedToMatchAll, findings), nil
 }
 
+// runConditionalMatch works like runAndMatch, but if any of the forbidden regex expressions match the file no finding
+// will be returned, since the forbidden content is what makes the code safe
+func (r *Rule) runConditionalMatch(file *File) ([]engine.Finding, error) {
+	for _, expression := range r.ForbiddenExpressions {
+		if expression.Match(file.Content) {
+			return nil, nil
+		}
+	}
+
+	return r.runAndMatch(file)
+}
+
 // getFirstFindingIfAllMatched checks if all regex expressions matched, if not will return nil. In case of all of them
 // have match will get the first finding of the slice and return it to be used to generate the report
 func (r *Rule) getFirstFindingIfAllMatched(isFailedToMatchAll bool, findings []engine.Finding) []engine.Finding {
@@ -175,9 +376,9 @@ func (r *Rule) runOrMatch(file *File) ([]engine.Finding, error) {
 	var findings []engine.Finding
 
 	for _, expression := range r.Expressions {
-		findingIndexes := expression.FindAllIndex(file.Content, -1)
+		findingIndexes := expression.FindAllSubmatchIndex(file.Content, -1)
 		if findingIndexes != nil {
-			findings = append(findings, r.createFindingsFromIndexes(findingIndexes, file)...)
+			findings = append(findings, r.createFindingsFromIndexes(expression, findingIndexes, file)...)
 
 			continue
 		}
@@ -186,24 +387,166 @@ func (r *Rule) runOrMatch(file *File) ([]engine.Finding, error) {
 	return findings, nil
 }
 
-// createFindingsFromIndexes for each index found of a possible vulnerability will get the line, column and code sample
-// and create a new finding to append into the result
-func (r *Rule) createFindingsFromIndexes(findingIndexes [][]int, file *File) (findings []engine.Finding) {
+// createFindingsFromIndexes for each index found of a possible vulnerability will get the line, column, code sample
+// and capture groups values and create a new finding to append into the result
+func (r *Rule) createFindingsFromIndexes(expression *regexp.Regexp, findingIndexes [][]int,
+	file *File) (findings []engine.Finding) {
 	for _, findingIndex := range findingIndexes {
-		line, column := file.FindLineAndColumn(findingIndex[0])
-		codeSample := file.ExtractSample(findingIndex[0])
+		if r.isFollowedByForbiddenContent(file, findingIndex[1]) || !r.isValidCommentMatch(file, findingIndex[0]) {
+			continue
+		}
+
+		findings = append(findings, r.newMatchFinding(expression, findingIndex, file))
+	}
+
+	return findings
+}
+
+// newMatchFinding creates the finding of the match of the regex expression with its position, code sample, capture
+// groups values and fix
+func (r *Rule) newMatchFinding(expression *regexp.Regexp, findingIndex []int, file *File) engine.Finding {
+	line, column := file.FindLineAndColumn(findingIndex[0])
+
+	finding := r.newFinding(file.RelativePath, r.extractSample(file, findingIndex), line, column)
+	finding.SourceLocation.Offset = findingIndex[0]
+	finding.Captures = r.getCaptures(expression, findingIndex, file)
+	finding.Fix = r.getFix(expression, findingIndex, file)
+
+	return finding
+}
 
-		findings = append(findings, r.newFinding(
-			file.RelativePath,
-			codeSample,
-			line,
-			column,
-		))
+// applyPositionBase converts the 1-based lines and 0-based columns of the findings, as returned by
+// File.FindLineAndColumn, to the position base of the rule. Findings without a position are kept with line 0
+func (r *Rule) applyPositionBase(findings []engine.Finding) []engine.Finding {
+	for index := range findings {
+		findings[index].SourceLocation = findings[index].SourceLocation.WithBase(r.PositionBase)
 	}
 
 	return findings
 }
 
+// extractSample returns the code sample of the match, with the matched content redacted if the rule is sensitive
+func (r *Rule) extractSample(file *File, findingIndex []int) string {
+	if !r.Sensitive {
+		return file.ExtractSample(findingIndex[0])
+	}
+
+	return file.extractRedactedSample(findingIndex, r.RedactKeepChars)
+}
+
+// getFix returns the edit suggested to replace the matched content, expanding the capture groups referenced by the fix
+// template. It returns nil if the rule doesn't have a fix template or if the fix would expose the secret of a sensitive
+// rule
+func (r *Rule) getFix(expression *regexp.Regexp, findingIndex []int, file *File) *engine.Fix {
+	if r.FixTemplate == "" {
+		return nil
+	}
+
+	replacement := string(expression.Expand(nil, []byte(r.FixTemplate), file.Content, findingIndex))
+	if r.Sensitive && containsMatchedContent(replacement, findingIndex, file.Content) {
+		return nil
+	}
+
+	return &engine.Fix{
+		StartOffset: findingIndex[0],
+		EndOffset:   findingIndex[1],
+		Replacement: replacement,
+	}
+}
+
+// withCommentRegions returns a copy of the file holding its comment regions, since they are only needed by the current
+// rule they are not stored in the original file
+func (r *Rule) withCommentRegions(file *File) *File {
+	syntax := DefaultCommentSyntax
+	if r.CommentSyntax != nil {
+		syntax = *r.CommentSyntax
+	}
+
+	withComments := *file
+	withComments.commentRegions = file.CommentRegions(syntax)
+
+	return &withComments
+}
+
+// isValidCommentMatch checks if the match start is in a valid place according to the comment mode
+func (r *Rule) isValidCommentMatch(file *File, matchStart int) bool {
+	switch r.CommentMode {
+	case MatchOnlyComments:
+		return isInComment(file.commentRegions, matchStart)
+	case MatchExcludeComments:
+		return !isInComment(file.commentRegions, matchStart)
+	case MatchAnywhere:
+		return true
+	}
+
+	return true
+}
+
+// isFollowedByForbiddenContent checks if the NotFollowedBy regex expression matches the content starting exactly at
+// the end of the match
+func (r *Rule) isFollowedByForbiddenContent(file *File, matchEnd int) bool {
+	if r.NotFollowedBy == nil {
+		return false
+	}
+
+	// the content right after the match is searched in the whole file, even if only a prefix of it was analyzed
+	return r.getAnchoredNotFollowedBy().Match(file.whole().Content[matchEnd:])
+}
+
+// getAnchoredNotFollowedBy returns NotFollowedBy anchored to the beginning of the content, anchoring it only once when
+// it was not set by WithNotFollowedBy. Unanchored expressions would otherwise search the whole rest of the file
+func (r *Rule) getAnchoredNotFollowedBy() *regexp.Regexp {
+	r.anchorOnce.Do(func() {
+		if r.anchoredNotFollowedBy != r.NotFollowedBy {
+			r.anchoredNotFollowedBy = regexp.MustCompile(`^(?:` + r.NotFollowedBy.String() + `)`)
+		}
+	})
+
+	return r.anchoredNotFollowedBy
+}
+
+// getCaptures get the value of each capture group using the submatch indexes, named groups are identified by their
+// name and unnamed groups by their number. Groups that didn't participate in the match are ignored
+func (r *Rule) getCaptures(expression *regexp.Regexp, findingIndex []int, file *File) map[string]string {
+	if expression.NumSubexp() == 0 {
+		return nil
+	}
+
+	captures := make(map[string]string, expression.NumSubexp())
+
+	for index, name := range expression.SubexpNames()[1:] {
+		if value, ok := r.getCaptureValue(findingIndex, index+1, file); ok {
+			captures[captureName(index+1, name)] = value
+		}
+	}
+
+	return captures
+}
+
+// captureName returns the name of the capture group, or its number if it's an unnamed group
+func captureName(group int, name string) string {
+	if name == "" {
+		return strconv.Itoa(group)
+	}
+
+	return name
+}
+
+// getCaptureValue returns the value of the capture group, redacted if the rule is sensitive, or false if the group
+// didn't participate in the match
+func (r *Rule) getCaptureValue(findingIndex []int, group int, file *File) (string, bool) {
+	start, end := findingIndex[2*group], findingIndex[2*group+1]
+	if start < 0 {
+		return "", false
+	}
+
+	if r.Sensitive {
+		return redactValue(string(file.Content[start:end]), r.RedactKeepChars), true
+	}
+
+	return string(file.Content[start:end]), true
+}
+
 // newFinding create a new finding with the information of the vulnerability obtained from the file
 func (r *Rule) newFinding(filename, codeSample string, line, column int) engine.Finding {
 	return engine.Finding{
@@ -212,6 +555,8 @@ func (r *Rule) newFinding(filename, codeSample string, line, column int) engine.
 		Severity:    r.Severity,
 		Confidence:  r.Confidence,
 		Description: r.Description,
+		CWEs:        r.CWEs,
+		Tags:        r.Tags,
 		CodeSample:  codeSample,
 		SourceLocation: engine.Location{
 			Filename: filename,
@@ -224,12 +569,12 @@ func (r *Rule) newFinding(filename, codeSample string, line, column int) engine.
 // isBinary verify if the file being analyzed is a binary file
 func (r *Rule) isBinary(content []byte) bool {
 	// Ignore Linux binaries
-	if bytes.Equal(content[:4], elfMagicNumber) {
+	if bytes.HasPrefix(content, elfMagicNumber) {
 		return true
 	}
 
 	// Ignore Windows binaries
-	if bytes.Equal(content[:2], peMagicBytes) {
+	if bytes.HasPrefix(content, peMagicBytes) {
 		return true
 	}
 
@@ -15,11 +15,17 @@
 package text
 
 import (
+	"context"
+	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	engine "github.com/ZupIT/horusec-engine"
 )
 
 func TestRun(t *testing.T) {
@@ -169,3 +175,693 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRunConditionalMatch(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expressions          []*regexp.Regexp
+		forbiddenExpressions []*regexp.Regexp
+		expectedFindings     int
+	}{
+		{
+			name:                 "Should return 1 finding when required matches and forbidden is absent",
+			expressions:          []*regexp.Regexp{regexp.MustCompile(`createServer`), regexp.MustCompile(`listen`)},
+			forbiddenExpressions: []*regexp.Regexp{regexp.MustCompile(`https\.createServer`)},
+			expectedFindings:     1,
+		},
+		{
+			name:                 "Should return 0 findings when required matches and forbidden is present",
+			expressions:          []*regexp.Regexp{regexp.MustCompile(`createServer`)},
+			forbiddenExpressions: []*regexp.Regexp{regexp.MustCompile(`should-not-match`), regexp.MustCompile(`hostname`)},
+			expectedFindings:     0,
+		},
+		{
+			name:                 "Should return 0 findings when required is absent and forbidden is absent",
+			expressions:          []*regexp.Regexp{regexp.MustCompile(`createServer`), regexp.MustCompile(`should-not-match`)},
+			forbiddenExpressions: []*regexp.Regexp{regexp.MustCompile(`https\.createServer`)},
+			expectedFindings:     0,
+		},
+		{
+			name:                 "Should return 0 findings when required is absent and forbidden is present",
+			expressions:          []*regexp.Regexp{regexp.MustCompile(`should-not-match`)},
+			forbiddenExpressions: []*regexp.Regexp{regexp.MustCompile(`hostname`)},
+			expectedFindings:     0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("server.js", []byte(sampleJs))
+			assert.NoError(t, err)
+
+			rule := &Rule{
+				Type:                 ConditionalMatch,
+				Expressions:          testCase.expressions,
+				ForbiddenExpressions: testCase.forbiddenExpressions,
+			}
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+			assert.Len(t, findings, testCase.expectedFindings)
+		})
+	}
+}
+
+func TestRunCaptures(t *testing.T) {
+	testCases := []struct {
+		name             string
+		expression       *regexp.Regexp
+		expectedCaptures []map[string]string
+	}{
+		{
+			name:       "Should capture named and numbered groups",
+			expression: regexp.MustCompile(`const (?P<name>\w+) = ('?[\w.]+'?)`),
+			expectedCaptures: []map[string]string{
+				{"name": "http", "2": "require"},
+				{"name": "hostname", "2": "'127.0.0.1'"},
+				{"name": "port", "2": "3000"},
+				{"name": "server", "2": "http.createServer"},
+			},
+		},
+		{
+			name:       "Should ignore groups that did not participate in the match",
+			expression: regexp.MustCompile(`(?P<secure>https)?\.createServer`),
+			expectedCaptures: []map[string]string{
+				{},
+			},
+		},
+		{
+			name:             "Should not set captures when the expression does not have groups",
+			expression:       regexp.MustCompile(`server\.listen`),
+			expectedCaptures: []map[string]string{nil},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("server.js", []byte(sampleJs))
+			assert.NoError(t, err)
+
+			rule := &Rule{
+				Type:        OrMatch,
+				Expressions: []*regexp.Regexp{testCase.expression},
+			}
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+
+			var captures []map[string]string
+			for _, finding := range findings {
+				captures = append(captures, finding.Captures)
+			}
+
+			assert.Equal(t, testCase.expectedCaptures, captures)
+		})
+	}
+}
+
+func TestRunNotMatchInFindingSet(t *testing.T) {
+	projectPath := t.TempDir()
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(projectPath, name), []byte("package main\n"), 0o600))
+	}
+
+	rule, err := NewRule("HORUSEC-1", NotMatch, []string{`use strict`, `license`})
+	assert.NoError(t, err)
+
+	findings, err := engine.NewEngine(0, ".go").Run(context.Background(), projectPath, rule)
+	assert.NoError(t, err)
+	assert.Len(t, findings, 6)
+
+	t.Run("Should keep each NotMatch finding of the same file in the finding set", func(t *testing.T) {
+		assert.Equal(t, 6, engine.NewFindingSet(findings...).Len())
+	})
+}
+
+func TestRunNotMatchLine(t *testing.T) {
+	content := "// license: apache\n// license: apache\r\nconst a = 1\n\n// license: mit\nconst b = 2"
+
+	t.Run("Should report the lines that don't match the expressions", func(t *testing.T) {
+		file, err := NewTextFile("main.js", []byte(content))
+		assert.NoError(t, err)
+
+		rule := &Rule{
+			Type: NotMatchLine,
+			Expressions: []*regexp.Regexp{
+				regexp.MustCompile(`^//`),
+				regexp.MustCompile(`license: apache$`),
+			},
+		}
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 3)
+
+		var lines []int
+		var samples []string
+		for _, finding := range findings {
+			lines = append(lines, finding.SourceLocation.Line)
+			samples = append(samples, finding.CodeSample)
+
+			assert.True(t, strings.HasPrefix(content[finding.SourceLocation.Offset:], finding.CodeSample))
+		}
+
+		assert.Equal(t, []int{3, 5, 6}, lines)
+		assert.Equal(t, []string{"const a = 1", "// license: mit", "const b = 2"}, samples)
+	})
+
+	t.Run("Should return 0 findings when all lines match", func(t *testing.T) {
+		file, err := NewTextFile("main.js", []byte(content))
+		assert.NoError(t, err)
+
+		rule := &Rule{
+			Type:        NotMatchLine,
+			Expressions: []*regexp.Regexp{regexp.MustCompile(`.`)},
+		}
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+}
+
+func TestRunTags(t *testing.T) {
+	testCases := []struct {
+		name         string
+		metadata     engine.Metadata
+		expectedCWEs []string
+		expectedTags []string
+	}{
+		{
+			name:         "Should propagate tags and cwes from rule to finding",
+			metadata:     engine.Metadata{CWEs: []string{"CWE-798"}, Tags: []string{"secrets", "crypto"}},
+			expectedCWEs: []string{"CWE-798"},
+			expectedTags: []string{"secrets", "crypto"},
+		},
+		{
+			name:     "Should return finding without tags and cwes when rule does not have them",
+			metadata: engine.Metadata{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("server.js", []byte(sampleJs))
+			assert.NoError(t, err)
+
+			rule := &Rule{
+				Metadata:    testCase.metadata,
+				Type:        OrMatch,
+				Expressions: []*regexp.Regexp{regexp.MustCompile(`server\.listen`)},
+			}
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+			assert.Len(t, findings, 1)
+			assert.Equal(t, testCase.expectedCWEs, findings[0].CWEs)
+			assert.Equal(t, testCase.expectedTags, findings[0].Tags)
+		})
+	}
+}
+
+func TestRunWithScanPrefixBytes(t *testing.T) {
+	content := "// Copyright 2022 Horusec\n// Licensed under the Apache License\npackage main\n\n// Copyright 2021 Other\n"
+
+	testCases := []struct {
+		name            string
+		scanPrefixBytes int
+		expectedLines   []int
+		expectedColumns []int
+		expectedSamples []string
+	}{
+		{
+			name:            "Should not report matches beyond the prefix",
+			scanPrefixBytes: 30,
+			expectedLines:   []int{1},
+			expectedColumns: []int{3},
+			expectedSamples: []string{"// Copyright 2022 Horusec"},
+		},
+		{
+			name:            "Should report matches in the last line of the prefix",
+			scanPrefixBytes: len(content) - 6,
+			expectedLines:   []int{1, 5},
+			expectedColumns: []int{3, 3},
+			expectedSamples: []string{"// Copyright 2022 Horusec", "// Copyright 2021 Other"},
+		},
+		{
+			name:            "Should analyze the whole file when prefix is not set",
+			expectedLines:   []int{1, 5},
+			expectedColumns: []int{3, 3},
+			expectedSamples: []string{"// Copyright 2022 Horusec", "// Copyright 2021 Other"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("main.go", []byte(content))
+			assert.NoError(t, err)
+
+			rule := &Rule{
+				Type:            OrMatch,
+				Expressions:     []*regexp.Regexp{regexp.MustCompile(`Copyright \d+`)},
+				ScanPrefixBytes: testCase.scanPrefixBytes,
+			}
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+
+			var lines, columns []int
+			var samples []string
+			for _, finding := range findings {
+				lines = append(lines, finding.SourceLocation.Line)
+				columns = append(columns, finding.SourceLocation.Column)
+				samples = append(samples, finding.CodeSample)
+			}
+
+			assert.Equal(t, testCase.expectedLines, lines)
+			assert.Equal(t, testCase.expectedColumns, columns)
+			assert.Equal(t, testCase.expectedSamples, samples)
+			assert.Equal(t, content, string(file.Content))
+		})
+	}
+}
+
+func TestRunWithScanPrefixBytesCuttingLine(t *testing.T) {
+	t.Run("Should check the whole last line of the prefix with NotMatchLine", func(t *testing.T) {
+		file, err := NewTextFile("main.go", []byte("// ok\n// ok more\n"))
+		assert.NoError(t, err)
+
+		rule := &Rule{
+			Type:            NotMatchLine,
+			Expressions:     []*regexp.Regexp{regexp.MustCompile(`^// ok`)},
+			ScanPrefixBytes: 8,
+		}
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("Should search NotFollowedBy beyond the end of the prefix", func(t *testing.T) {
+		file, err := NewTextFile("main.go", []byte("hash := cipher.md5(data)\n"))
+		assert.NoError(t, err)
+
+		rule, err := NewRule("HORUSEC-1", OrMatch, []string{`cipher`}, WithScanPrefixBytes(14),
+			WithNotFollowedBy(`\.md5`))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+}
+
+func TestRunFingerprint(t *testing.T) {
+	content := "package main\n\nfunc main() {\n\texec(cmd)\n}\n"
+	changedContent := "package main\n\nimport \"os\"\n\n// run the command\nfunc main() {\n\texec(cmd)\n}\n"
+
+	run := func(content string) engine.Finding {
+		file, err := NewTextFile("main.go", []byte(content))
+		assert.NoError(t, err)
+
+		rule := &Rule{
+			Metadata:    engine.Metadata{ID: "HORUSEC-1"},
+			Type:        OrMatch,
+			Expressions: []*regexp.Regexp{regexp.MustCompile(`exec\(`)},
+		}
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+
+		return findings[0]
+	}
+
+	t.Run("Should keep the fingerprint when unrelated lines are inserted above the match", func(t *testing.T) {
+		finding, changedFinding := run(content), run(changedContent)
+
+		assert.NotEqual(t, finding.SourceLocation.Line, changedFinding.SourceLocation.Line)
+		assert.Equal(t, finding.Fingerprint(), changedFinding.Fingerprint())
+	})
+}
+
+func TestNewRule(t *testing.T) {
+	testCases := []struct {
+		name          string
+		matchType     MatchType
+		patterns      []string
+		opts          []Option
+		expectedError string
+	}{
+		{
+			name:      "Should create a rule with valid patterns",
+			matchType: OrMatch,
+			patterns:  []string{`exec\(`, `eval\(`},
+		},
+		{
+			name:          "Should return error with the index of the invalid pattern",
+			matchType:     OrMatch,
+			patterns:      []string{`exec\(`, `eval(`},
+			expectedError: "invalid pattern at index 1: error parsing regexp: missing closing ): `eval(`",
+		},
+		{
+			name:          "Should return error when there are no patterns",
+			matchType:     OrMatch,
+			expectedError: "at least one pattern is required",
+		},
+		{
+			name:          "Should return error when rule type is invalid",
+			matchType:     MatchType(100),
+			patterns:      []string{`exec\(`},
+			expectedError: "invalid rule type 100",
+		},
+		{
+			name:          "Should return error when option fails",
+			matchType:     ConditionalMatch,
+			patterns:      []string{`createHash\(`},
+			opts:          []Option{WithForbiddenPatterns(`sha256`, `[`)},
+			expectedError: "invalid pattern at index 1: error parsing regexp: missing closing ]: `[`",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := NewRule("HORUSEC-1", testCase.matchType, testCase.patterns, testCase.opts...)
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.Nil(t, rule)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "HORUSEC-1", rule.ID)
+			assert.Equal(t, testCase.matchType, rule.Type)
+			assert.Len(t, rule.Expressions, len(testCase.patterns))
+		})
+	}
+
+	t.Run("Should apply options to the rule", func(t *testing.T) {
+		rule, err := NewRule("HORUSEC-1", ConditionalMatch, []string{`createHash\(`},
+			WithMetadata(engine.Metadata{ID: "OTHER", Name: "Weak hash"}),
+			WithForbiddenPatterns(`sha256`),
+			WithSuppressionMarker(DefaultSuppressionMarker),
+			WithColumnUnit(ByteColumn),
+			WithScanPrefixBytes(1024),
+			WithCommentMode(MatchExcludeComments),
+			WithCommentSyntax(CommentSyntax{LineMarkers: []string{"--"}}),
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, engine.Metadata{ID: "HORUSEC-1", Name: "Weak hash"}, rule.Metadata)
+		assert.Equal(t, []*regexp.Regexp{regexp.MustCompile(`sha256`)}, rule.ForbiddenExpressions)
+		assert.Equal(t, DefaultSuppressionMarker, rule.SuppressionMarker)
+		assert.Equal(t, ByteColumn, rule.ColumnUnit)
+		assert.Equal(t, 1024, rule.ScanPrefixBytes)
+		assert.Equal(t, MatchExcludeComments, rule.CommentMode)
+		assert.Equal(t, &CommentSyntax{LineMarkers: []string{"--"}}, rule.CommentSyntax)
+	})
+}
+
+func TestRunWithNotFollowedBy(t *testing.T) {
+	content := "cipher := des.NewCipher(key)\nhash := md5.New()\nhash := md5.Sum(data)\nhash = md5\n"
+
+	testCases := []struct {
+		name          string
+		pattern       string
+		notFollowedBy string
+		expectedLines []int
+		expectedError bool
+	}{
+		{
+			name:          "Should ignore matches followed by the forbidden content",
+			pattern:       `md5`,
+			notFollowedBy: `\.Sum\(`,
+			expectedLines: []int{2, 4},
+		},
+		{
+			name:          "Should ignore matches only when the forbidden content is right after the match",
+			pattern:       `des`,
+			notFollowedBy: `Cipher`,
+			expectedLines: []int{1},
+		},
+		{
+			name:          "Should report all matches without forbidden content",
+			pattern:       `md5`,
+			expectedLines: []int{2, 3, 4},
+		},
+		{
+			name:          "Should return error when not followed by pattern is invalid",
+			pattern:       `md5`,
+			notFollowedBy: `(`,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var opts []Option
+			if testCase.notFollowedBy != "" {
+				opts = append(opts, WithNotFollowedBy(testCase.notFollowedBy))
+			}
+
+			rule, err := NewRule("HORUSEC-1", OrMatch, []string{testCase.pattern}, opts...)
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			file, err := NewTextFile("main.go", []byte(content))
+			assert.NoError(t, err)
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+
+			var lines []int
+			for _, finding := range findings {
+				lines = append(lines, finding.SourceLocation.Line)
+			}
+
+			assert.Equal(t, testCase.expectedLines, lines)
+		})
+	}
+
+	t.Run("Should not report AndMatch when all matches of an expression are followed by forbidden", func(t *testing.T) {
+		rule, err := NewRule("HORUSEC-1", AndMatch, []string{`cipher`, `md5`}, WithNotFollowedBy(`\.\w+\(`))
+		assert.NoError(t, err)
+
+		file, err := NewTextFile("main.go", []byte("cipher := 1\nhash := md5.Sum(data)\n"))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("Should anchor to the end of the match a NotFollowedBy set without the option", func(t *testing.T) {
+		rule := &Rule{
+			Type:          OrMatch,
+			Expressions:   []*regexp.Regexp{regexp.MustCompile(`console`)},
+			NotFollowedBy: regexp.MustCompile(`\.error|\.warn`),
+		}
+
+		file, err := NewTextFile("main.js", []byte("console.log(a)\nconsole.error(b)\nconsole.warn(c)\n"))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, 1, findings[0].SourceLocation.Line)
+		assert.Equal(t, `^(?:\.error|\.warn)`, rule.getAnchoredNotFollowedBy().String())
+	})
+
+	t.Run("Should not anchor again a NotFollowedBy set by the option", func(t *testing.T) {
+		rule, err := NewRule("HORUSEC-1", OrMatch, []string{`console`}, WithNotFollowedBy(`\.error`))
+		assert.NoError(t, err)
+		assert.Same(t, rule.NotFollowedBy, rule.getAnchoredNotFollowedBy())
+	})
+}
+
+func TestRunConcurrently(t *testing.T) {
+	file, err := NewTextFile("server.js", []byte(sampleJs))
+	assert.NoError(t, err)
+
+	rules := []*Rule{
+		{Type: OrMatch, Expressions: []*regexp.Regexp{regexp.MustCompile(`res\.(?P<method>\w+)`)}},
+		{Type: AndMatch, Expressions: []*regexp.Regexp{regexp.MustCompile(`hostname`), regexp.MustCompile(`port`)}},
+		{Type: NotMatchLine, Expressions: []*regexp.Regexp{regexp.MustCompile(`const`)}},
+		{Type: OrMatch, Expressions: []*regexp.Regexp{regexp.MustCompile(`listen`)}, ScanPrefixBytes: 100},
+		{
+			Type:              OrMatch,
+			Expressions:       []*regexp.Regexp{regexp.MustCompile(`console`)},
+			SuppressionMarker: DefaultSuppressionMarker,
+			NotFollowedBy:     regexp.MustCompile(`^\.error`),
+		},
+	}
+
+	expectedFindings := make([][]engine.Finding, len(rules))
+	for index, rule := range rules {
+		expectedFindings[index], err = rule.RunFile(file)
+		assert.NoError(t, err)
+	}
+
+	t.Run("Should return the same findings when running rules concurrently on the same file", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+
+		for i := 0; i < 50; i++ {
+			for index, rule := range rules {
+				wg.Add(1)
+
+				go func(index int, rule *Rule) {
+					defer wg.Done()
+
+					findings, err := rule.RunFile(file)
+					assert.NoError(t, err)
+					assert.Equal(t, expectedFindings[index], findings)
+				}(index, rule)
+			}
+		}
+
+		wg.Wait()
+	})
+}
+
+func TestRunOffset(t *testing.T) {
+	content := "const a = 1\r\nconst b = eval(a)\n\teval(b)"
+
+	file, err := NewTextFile("main.js", []byte(content))
+	assert.NoError(t, err)
+
+	rule := &Rule{
+		Type:        Regular,
+		Expressions: []*regexp.Regexp{regexp.MustCompile(`eval\(`)},
+	}
+
+	findings, err := rule.RunFile(file)
+	assert.NoError(t, err)
+	assert.Len(t, findings, 2)
+
+	for _, finding := range findings {
+		line, column := file.FindLineAndColumn(finding.SourceLocation.Offset)
+
+		assert.Equal(t, finding.SourceLocation.Line, line)
+		assert.Equal(t, finding.SourceLocation.Column, column)
+		assert.True(t, strings.HasPrefix(content[finding.SourceLocation.Offset:], "eval("))
+	}
+}
+
+func TestRunWithFixTemplate(t *testing.T) {
+	content := "import hashlib\n\ndigest = hashlib.md5(password).hexdigest()\n"
+
+	file, err := NewTextFile("main.py", []byte(content))
+	assert.NoError(t, err)
+
+	t.Run("Should suggest a fix replacing the matched content", func(t *testing.T) {
+		rule, err := NewRule("HS-PY-1", Regular, []string{`hashlib\.md5\((?P<data>[^)]*)\)`},
+			WithFixTemplate("hashlib.sha256(${data})"))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+
+		fix := findings[0].Fix
+		assert.NotNil(t, fix)
+		assert.Equal(t, "hashlib.md5(password)", content[fix.StartOffset:fix.EndOffset])
+		assert.Equal(t, "hashlib.sha256(password)", fix.Replacement)
+	})
+
+	t.Run("Should not suggest a fix without a fix template", func(t *testing.T) {
+		rule, err := NewRule("HS-PY-1", Regular, []string{`hashlib\.md5\(`})
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Nil(t, findings[0].Fix)
+	})
+}
+
+func TestRunWithPositionBase(t *testing.T) {
+	content := "package main\n\nfunc main() {\n\tpanic(\"error\")\n}\n// license: none"
+
+	file, err := NewTextFile("main.go", []byte(content))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		base           engine.PositionBase
+		expectedLine   int
+		expectedColumn int
+	}{
+		{
+			name:           "Should report 1-based lines and 0-based columns by default",
+			base:           engine.DefaultBase,
+			expectedLine:   4,
+			expectedColumn: 1,
+		},
+		{
+			name:           "Should report 0-based lines and columns",
+			base:           engine.ZeroBased,
+			expectedLine:   3,
+			expectedColumn: 1,
+		},
+		{
+			name:           "Should report 1-based lines and columns",
+			base:           engine.OneBased,
+			expectedLine:   4,
+			expectedColumn: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := NewRule("HS-GO-1", Regular, []string{`panic\(`}, WithPositionBase(testCase.base))
+			assert.NoError(t, err)
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+			assert.Len(t, findings, 1)
+			assert.Equal(t, testCase.expectedLine, findings[0].SourceLocation.Line)
+			assert.Equal(t, testCase.expectedColumn, findings[0].SourceLocation.Column)
+		})
+	}
+
+	t.Run("Should report the lines of NotMatchLine in the same base", func(t *testing.T) {
+		rule, err := NewRule("HS-GO-2", NotMatchLine, []string{`^package`}, WithPositionBase(engine.ZeroBased))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 4)
+		assert.Equal(t, 2, findings[0].SourceLocation.Line)
+		assert.Equal(t, 0, findings[0].SourceLocation.Column)
+	})
+
+	t.Run("Should suppress the findings by the line above in any base", func(t *testing.T) {
+		suppressed, err := NewTextFile("main.go", []byte("// nosec\npanic(\"error\")\n"))
+		assert.NoError(t, err)
+
+		rule, err := NewRule("HS-GO-1", Regular, []string{`panic\(`},
+			WithPositionBase(engine.ZeroBased), WithSuppressionMarker(DefaultSuppressionMarker))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(suppressed)
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("Should report findings without position with line and column 0", func(t *testing.T) {
+		rule, err := NewRule("HS-GO-3", NotMatch, []string{`should-not-match`}, WithPositionBase(engine.OneBased))
+		assert.NoError(t, err)
+
+		findings, err := rule.RunFile(file)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, 0, findings[0].SourceLocation.Line)
+		assert.Equal(t, 0, findings[0].SourceLocation.Column)
+	})
+}
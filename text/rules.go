@@ -0,0 +1,75 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import engine "github.com/ZupIT/horusec-engine"
+
+// Rules represents a group of rules that are applied together over the same files. Different from running each rule by
+// itself, the file content is read and the text file created only once, then all rules are applied to it keeping the
+// semantics of their match types. It implements engine.GroupRule, so it can be informed to the engine as a single rule
+// and the engine still filters each rule by its id
+type Rules []*Rule
+
+// FilterRules returns only the rules accepted by isAllowed, or nil if none of them is accepted
+func (rs Rules) FilterRules(isAllowed func(rule engine.Rule) bool) engine.Rule {
+	var filtered Rules
+
+	for _, rule := range rs {
+		if isAllowed(rule) {
+			filtered = append(filtered, rule)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	return filtered
+}
+
+// Run reads the file once and returns the findings of all rules, in the order of the rules
+func (rs Rules) Run(path string) ([]engine.Finding, error) {
+	if len(rs) == 0 {
+		return nil, nil
+	}
+
+	content, err := rs[0].getFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+
+	textFile, err := NewTextFile(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.RunFile(textFile)
+}
+
+// RunFile applies all rules over a text file that was already created
+func (rs Rules) RunFile(file *File) ([]engine.Finding, error) {
+	var findings []engine.Finding
+
+	for _, rule := range rs {
+		ruleFindings, err := rule.RunFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		findings = append(findings, ruleFindings...)
+	}
+
+	return findings, nil
+}
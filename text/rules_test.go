@@ -0,0 +1,122 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+func TestRulesRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.js")
+	assert.NoError(t, os.WriteFile(path, []byte("const a = eval(input)\nconsole.log(a)\n"), 0o600))
+
+	regular, err := NewRule("HS-JS-1", Regular, []string{`eval\(`})
+	assert.NoError(t, err)
+
+	notMatch, err := NewRule("HS-JS-2", NotMatch, []string{`use strict`})
+	assert.NoError(t, err)
+
+	orMatch, err := NewRule("HS-JS-3", OrMatch, []string{`should-not-match`})
+	assert.NoError(t, err)
+
+	rules := Rules{regular, notMatch, orMatch}
+
+	t.Run("Should return the findings of all rules keeping their match types", func(t *testing.T) {
+		findings, err := rules.Run(path)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 2)
+
+		assert.Equal(t, "HS-JS-1", findings[0].ID)
+		assert.Equal(t, 1, findings[0].SourceLocation.Line)
+		assert.Equal(t, 10, findings[0].SourceLocation.Column)
+
+		assert.Equal(t, "HS-JS-2", findings[1].ID)
+		assert.Equal(t, 0, findings[1].SourceLocation.Line)
+	})
+
+	t.Run("Should return the same findings as running each rule by itself", func(t *testing.T) {
+		var expected []engine.Finding
+
+		for _, rule := range rules {
+			findings, err := rule.Run(path)
+			assert.NoError(t, err)
+
+			expected = append(expected, findings...)
+		}
+
+		findings, err := rules.Run(path)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, findings)
+	})
+
+	t.Run("Should run as a single rule in the engine", func(t *testing.T) {
+		findings, err := engine.NewEngine(0, ".js").Run(context.Background(), filepath.Dir(path), rules)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 2)
+	})
+
+	t.Run("Should filter each rule by id in the engine", func(t *testing.T) {
+		testCases := []struct {
+			allowed     []string
+			denied      []string
+			expectedIDs []string
+		}{
+			{allowed: []string{"HS-JS-1"}, expectedIDs: []string{"HS-JS-1"}},
+			{denied: []string{"HS-JS-1"}, expectedIDs: []string{"HS-JS-2"}},
+			{allowed: []string{"HS-JS-1", "HS-JS-2"}, denied: []string{"HS-JS-2"}, expectedIDs: []string{"HS-JS-1"}},
+			{allowed: []string{"HS-OTHER"}},
+		}
+
+		for _, testCase := range testCases {
+			analysis := engine.NewEngine(0, ".js")
+			analysis.SetRuleFilter(testCase.allowed, testCase.denied)
+
+			findings, err := analysis.Run(context.Background(), filepath.Dir(path), rules)
+			assert.NoError(t, err)
+
+			var ids []string
+			for _, finding := range findings {
+				ids = append(ids, finding.ID)
+			}
+
+			assert.Equal(t, testCase.expectedIDs, ids)
+		}
+	})
+
+	t.Run("Should filter each rule by id inside a rule set", func(t *testing.T) {
+		analysis := engine.NewEngine(0, ".js")
+		analysis.SetRuleFilter([]string{"HS-JS-2"}, nil)
+
+		set := engine.NewRuleSet("javascript", "1.0.0", rules)
+
+		findings, err := analysis.RunRuleSets(context.Background(), filepath.Dir(path), set)
+		assert.NoError(t, err)
+		assert.Len(t, findings, 1)
+		assert.Equal(t, "HS-JS-2", findings[0].ID)
+		assert.Equal(t, "javascript", findings[0].RuleSet.Name)
+	})
+
+	t.Run("Should return error when the file doesn't exist", func(t *testing.T) {
+		_, err := rules.Run(filepath.Join(t.TempDir(), "missing.js"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+// DefaultSuppressionMarker is the keyword commonly used to suppress findings in the line where the vulnerable code is
+// or in the line above it, like "// nosec" or "# nosec HORUSEC-EXAMPLE-1, HORUSEC-EXAMPLE-2"
+const DefaultSuppressionMarker = "nosec"
+
+// regexRuleIDSeparator regex representing the characters that can be used to separate rule ids after the marker
+var regexRuleIDSeparator = regexp.MustCompile(`[\s,]+`)
+
+// regexRuleIDs regex representing the leading run of id shaped tokens after the marker, anything after it like a
+// comment closer "*/" or "-->" is ignored
+var regexRuleIDs = regexp.MustCompile(`^[\s:]*([A-Za-z0-9_.-]+(?:[\s,]+[A-Za-z0-9_.-]+)*)?`)
+
+// regexRuleIDDigit regex representing the digit that tokens must have to be taken as ids of other rules, so free text
+// reasons like "nosec: false positive" suppress all rules
+var regexRuleIDDigit = regexp.MustCompile(`[0-9]`)
+
+// regexWordBoundary regex representing a single character that can be next to a \b word boundary
+var regexWordBoundary = regexp.MustCompile(`^\w$`)
+
+// suppressionMarkers caches the compiled regex of each suppression marker, so it's compiled only once and shared by
+// all rules and goroutines using the same marker
+var suppressionMarkers sync.Map
+
+// removeSuppressedFindings removes the findings that have a suppression marker in the same line or in the line above
+// it. If the marker is followed by rule ids, only the findings of those rules will be removed, a free text reason or a
+// comment closer after the marker removes the findings of all rules
+func (r *Rule) removeSuppressedFindings(file *File, findings []engine.Finding) []engine.Finding {
+	if r.SuppressionMarker == "" || len(findings) == 0 {
+		return findings
+	}
+
+	marker := compileSuppressionMarker(r.SuppressionMarker)
+	validFindings := make([]engine.Finding, 0, len(findings))
+
+	for _, finding := range findings {
+		if !r.isSuppressed(file, marker, finding.SourceLocation.Line) {
+			validFindings = append(validFindings, finding)
+		}
+	}
+
+	return validFindings
+}
+
+// isSuppressed checks if the line, or the line above it, suppresses the findings of the rule in the line
+func (r *Rule) isSuppressed(file *File, marker *regexp.Regexp, line int) bool {
+	return r.isLineSuppressed(file, marker, line) || r.isLineSuppressed(file, marker, line-1)
+}
+
+// compileSuppressionMarker returns the cached regex of the marker, compiling it on the first use. The marker is matched
+// the same way as markerPattern
+func compileSuppressionMarker(marker string) *regexp.Regexp {
+	if cached, ok := suppressionMarkers.Load(marker); ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	cached, _ := suppressionMarkers.LoadOrStore(marker, regexp.MustCompile(markerPattern(marker)+`(.*)`))
+
+	return cached.(*regexp.Regexp)
+}
+
+// markerPattern returns the quoted pattern of a comment marker, which must be a whole word only on its sides made of
+// word characters, so markers like "@nosec" or "@todo" still match right after "//"
+func markerPattern(marker string) string {
+	pattern := regexp.QuoteMeta(marker)
+
+	if marker == "" {
+		return pattern
+	}
+
+	if regexWordBoundary.MatchString(marker[:1]) {
+		pattern = `\b` + pattern
+	}
+
+	if regexWordBoundary.MatchString(marker[len(marker)-1:]) {
+		pattern += `\b`
+	}
+
+	return pattern
+}
+
+// isLineSuppressed checks if the line contains the suppression marker and if the marker applies to the rule id. Lines
+// are 1-based and the ones out of the file bounds are never suppressed
+func (r *Rule) isLineSuppressed(file *File, marker *regexp.Regexp, line int) bool {
+	// The suppression markers are searched in the whole lines, even if only a prefix of the file was analyzed
+	lineContent, ok := file.whole().Line(line)
+	if !ok {
+		return false
+	}
+
+	match := marker.FindStringSubmatch(lineContent)
+	if match == nil {
+		return false
+	}
+
+	return r.isSuppressedByRuleIDs(regexRuleIDs.FindStringSubmatch(match[1])[1])
+}
+
+// isSuppressedByRuleIDs checks if the tokens informed after the marker apply to the rule. The rule is suppressed when
+// one of the tokens is its id, or when none of them looks like the id of another rule, which is a token with a digit
+func (r *Rule) isSuppressedByRuleIDs(ruleIDs string) bool {
+	hasOtherRuleIDs := false
+
+	for _, ruleID := range regexRuleIDSeparator.Split(strings.TrimSpace(ruleIDs), -1) {
+		if ruleID == r.ID {
+			return true
+		}
+
+		hasOtherRuleIDs = hasOtherRuleIDs || regexRuleIDDigit.MatchString(ruleID)
+	}
+
+	return !hasOtherRuleIDs
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	engine "github.com/ZupIT/horusec-engine"
+)
+
+func TestRunWithSuppressionMarker(t *testing.T) {
+	content := `password := "first"
+password := "second" // nosec
+// nosec HORUSEC-1, HORUSEC-2
+password := "third"
+password := "fourth" // nosec HORUSEC-2
+# nosec HORUSEC-1 false positive
+password := "fifth"
+`
+
+	testCases := []struct {
+		name          string
+		marker        string
+		expectedLines []int
+	}{
+		{
+			name:          "Should remove suppressed findings of the rule",
+			marker:        DefaultSuppressionMarker,
+			expectedLines: []int{1, 5},
+		},
+		{
+			name:          "Should not remove findings when marker is empty",
+			marker:        "",
+			expectedLines: []int{1, 2, 4, 5, 7},
+		},
+		{
+			name:          "Should not remove findings when marker is different",
+			marker:        "horusec-ignore",
+			expectedLines: []int{1, 2, 4, 5, 7},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("main.go", []byte(content))
+			assert.NoError(t, err)
+
+			rule := &Rule{
+				Metadata:          engine.Metadata{ID: "HORUSEC-1"},
+				Type:              OrMatch,
+				Expressions:       []*regexp.Regexp{regexp.MustCompile(`password :=`)},
+				SuppressionMarker: testCase.marker,
+			}
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+
+			var lines []int
+			for _, finding := range findings {
+				lines = append(lines, finding.SourceLocation.Line)
+			}
+
+			assert.Equal(t, testCase.expectedLines, lines)
+		})
+	}
+}
+
+func TestRunWithNonWordSuppressionMarker(t *testing.T) {
+	content := `password := "first" // @nosec
+
+password := "second" // @nosecure
+
+password := "third" // @nosec HORUSEC-2
+
+password := "fourth" //@nosec HORUSEC-1
+`
+
+	file, err := NewTextFile("main.go", []byte(content))
+	assert.NoError(t, err)
+
+	rule := &Rule{
+		Metadata:          engine.Metadata{ID: "HORUSEC-1"},
+		Type:              OrMatch,
+		Expressions:       []*regexp.Regexp{regexp.MustCompile(`password :=`)},
+		SuppressionMarker: "@nosec",
+	}
+
+	findings, err := rule.RunFile(file)
+	assert.NoError(t, err)
+
+	var lines []int
+	for _, finding := range findings {
+		lines = append(lines, finding.SourceLocation.Line)
+	}
+
+	assert.Equal(t, []int{3, 5}, lines)
+}
+
+func TestRunWithSuppressionMarkerInsideComments(t *testing.T) {
+	testCases := []struct {
+		name       string
+		content    string
+		suppressed bool
+	}{
+		{name: "Should suppress with a block comment", content: "x = password /* nosec */", suppressed: true},
+		{name: "Should suppress with an html comment", content: "password <!-- nosec -->", suppressed: true},
+		{name: "Should suppress with a free text reason", content: "password // nosec: false positive", suppressed: true},
+		{
+			name:       "Should suppress with the rule id and a reason",
+			content:    "password /* nosec: HORUSEC-1 false positive */",
+			suppressed: true,
+		},
+		{name: "Should not suppress with other rule id in a block comment", content: "password /* nosec HORUSEC-2 */"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			file, err := NewTextFile("main.go", []byte(testCase.content+"\n"))
+			assert.NoError(t, err)
+
+			rule := &Rule{
+				Metadata:          engine.Metadata{ID: "HORUSEC-1"},
+				Type:              OrMatch,
+				Expressions:       []*regexp.Regexp{regexp.MustCompile(`password`)},
+				SuppressionMarker: DefaultSuppressionMarker,
+			}
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.suppressed, len(findings) == 0)
+		})
+	}
+}
+
+func TestCompileSuppressionMarker(t *testing.T) {
+	t.Run("Should compile each marker only once", func(t *testing.T) {
+		assert.Same(t, compileSuppressionMarker(DefaultSuppressionMarker), compileSuppressionMarker(DefaultSuppressionMarker))
+	})
+
+	t.Run("Should match the markers as whole words only on their word sides", func(t *testing.T) {
+		assert.True(t, compileSuppressionMarker("nosec").MatchString("// nosec"))
+		assert.False(t, compileSuppressionMarker("nosec").MatchString("// nosecure"))
+		assert.True(t, compileSuppressionMarker("@nosec").MatchString("//@nosec"))
+		assert.True(t, compileSuppressionMarker("nosec!").MatchString("// nosec!important"))
+		assert.False(t, compileSuppressionMarker("nosec!").MatchString("// xnosec!"))
+	})
+}
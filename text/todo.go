@@ -0,0 +1,52 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import "strings"
+
+const (
+	// TodoMarkerCapture is the capture name of the marker keyword (e.g. TODO) in the findings of a todo rule
+	TodoMarkerCapture = "marker"
+
+	// TodoAuthorCapture is the capture name of the optional author in parentheses in the findings of a todo rule
+	TodoAuthorCapture = "author"
+
+	// TodoMessageCapture is the capture name of the text after the marker in the findings of a todo rule
+	TodoMessageCapture = "message"
+)
+
+// DefaultTodoMarkers are the keywords used by NewTodoRule when none is informed
+var DefaultTodoMarkers = []string{"TODO", "FIXME"}
+
+// NewTodoRule creates a rule that reports technical debt markers like "// TODO(alice): fix this". Each finding holds
+// the marker, the author and the message in Finding.Captures, using the TodoMarkerCapture, TodoAuthorCapture and
+// TodoMessageCapture keys. The author is only present when informed in parentheses after the marker
+func NewTodoRule(id string, markers []string, opts ...Option) (*Rule, error) {
+	if len(markers) == 0 {
+		markers = DefaultTodoMarkers
+	}
+
+	markerPatterns := make([]string, 0, len(markers))
+	for _, marker := range markers {
+		markerPatterns = append(markerPatterns, markerPattern(marker))
+	}
+
+	// the message stops before a trailing block comment closer, like "*/" or "-->"
+	pattern := `(?m)(?P<` + TodoMarkerCapture + `>` + strings.Join(markerPatterns, "|") + `)` +
+		`(?:\((?P<` + TodoAuthorCapture + `>[^)\r\n]*)\))?:?[ \t]*` +
+		`(?P<` + TodoMessageCapture + `>[^\r\n]*?)[ \t]*(?:\*/|-->)?[ \t]*\r?$`
+
+	return NewRule(id, OrMatch, []string{pattern}, opts...)
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTodoRule(t *testing.T) {
+	content := `package main
+
+// TODO(alice): fix this
+func main() {
+	# FIXME handle the error
+	// HACK(bob): temporary
+	// TODOS is not a marker
+}
+`
+
+	testCases := []struct {
+		name             string
+		markers          []string
+		expectedCaptures []map[string]string
+	}{
+		{
+			name: "Should parse default markers with author and message",
+			expectedCaptures: []map[string]string{
+				{TodoMarkerCapture: "TODO", TodoAuthorCapture: "alice", TodoMessageCapture: "fix this"},
+				{TodoMarkerCapture: "FIXME", TodoMessageCapture: "handle the error"},
+			},
+		},
+		{
+			name:    "Should parse only the configured markers",
+			markers: []string{"HACK"},
+			expectedCaptures: []map[string]string{
+				{TodoMarkerCapture: "HACK", TodoAuthorCapture: "bob", TodoMessageCapture: "temporary"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := NewTodoRule("HORUSEC-TODO", testCase.markers)
+			assert.NoError(t, err)
+
+			file, err := NewTextFile("main.go", []byte(content))
+			assert.NoError(t, err)
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+
+			var captures []map[string]string
+			for _, finding := range findings {
+				captures = append(captures, finding.Captures)
+			}
+
+			assert.Equal(t, testCase.expectedCaptures, captures)
+		})
+	}
+}
+
+func TestNewTodoRuleMarkers(t *testing.T) {
+	testCases := []struct {
+		name            string
+		markers         []string
+		content         string
+		expectedMessage string
+	}{
+		{
+			name:            "Should match markers starting with non-word characters",
+			markers:         []string{"@todo"},
+			content:         "// @todo fix\n",
+			expectedMessage: "fix",
+		},
+		{
+			name:            "Should not include the block comment closer in the message",
+			content:         "/* TODO(bob): fix it */\n",
+			expectedMessage: "fix it",
+		},
+		{
+			name:            "Should not include the html comment closer in the message",
+			content:         "<!-- FIXME: broken link -->\r\n",
+			expectedMessage: "broken link",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := NewTodoRule("HORUSEC-TODO", testCase.markers)
+			assert.NoError(t, err)
+			assert.Empty(t, rule.Warnings)
+
+			file, err := NewTextFile("main.go", []byte(testCase.content))
+			assert.NoError(t, err)
+
+			findings, err := rule.RunFile(file)
+			assert.NoError(t, err)
+			assert.Len(t, findings, 1)
+			assert.Equal(t, testCase.expectedMessage, findings[0].Captures[TodoMessageCapture])
+		})
+	}
+}